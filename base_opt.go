@@ -73,3 +73,20 @@ func (s *BaseOpt) GetTitleNamesBy(delimChar string) string {
 	str := strings.Join(a, delimChar)
 	return str
 }
+
+// GetDescription returns the Description translated to the current
+// locale, see T() and AddTranslations()
+func (s *BaseOpt) GetDescription() string {
+	return T(s.Description)
+}
+
+// GetLongDescription returns the LongDescription translated to the
+// current locale
+func (s *BaseOpt) GetLongDescription() string {
+	return T(s.LongDescription)
+}
+
+// GetExamples returns the Examples translated to the current locale
+func (s *BaseOpt) GetExamples() string {
+	return T(s.Examples)
+}
@@ -56,6 +56,11 @@ func (w *ExecWorker) buildXref(rootCmd *RootCommand) (err error) {
 		if len(envPrefix) > 0 {
 			w.envPrefixes = envPrefix
 		}
+
+		// the dotenv files, and `--env-file xxx`
+		if err == nil {
+			err = w.loadEnvFiles(rootCmd)
+		}
 	}
 	return
 }
@@ -74,6 +79,7 @@ func (w *ExecWorker) buildRootCrossRefs(root *RootCommand) {
 	w.attachHelpCommands(root)
 	w.attachVerboseCommands(root)
 	w.attachGeneratorsCommands(root)
+	w.attachCompleteCommand(root)
 	w.attachCmdrCommands(root)
 
 	w.buildCrossRefs(&root.Command)
@@ -234,13 +240,106 @@ func (w *ExecWorker) attachHelpCommands(root *RootCommand) {
 					Hidden:      true,
 					Group:       SysMgmtGroup,
 					owner:       &root.Command,
-					Action:      dumpTreeForAllCommands,
+					Action:      requestTree,
 				},
 				DefaultValue: false,
 			}
 			root.Flags = append(root.Flags, ff)
 			root.allFlags[SysMgmtGroup]["tree"] = ff
 			root.plainLongFlags["tree"] = ff
+
+			ff = &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "tree-format",
+					Description: "the format of --tree: text, json, dot or mermaid",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+				},
+				DefaultValue:            "text",
+				DefaultValuePlaceholder: "FORMAT",
+				ValidArgs:               []string{"text", "json", "dot", "mermaid"},
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["tree-format"] = ff
+			root.plainLongFlags["tree-format"] = ff
+
+			ff = &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "tree-flags",
+					Description: "show the flags in --tree",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+				},
+				DefaultValue: false,
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["tree-flags"] = ff
+			root.plainLongFlags["tree-flags"] = ff
+
+			ff = &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "tree-hidden",
+					Description: "show the hidden commands and flags in --tree",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+				},
+				DefaultValue: false,
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["tree-hidden"] = ff
+			root.plainLongFlags["tree-hidden"] = ff
+
+			ff = &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "tree-group",
+					Description: "show the commands of the group only in --tree",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+				},
+				DefaultValue:            "",
+				DefaultValuePlaceholder: "GROUP",
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["tree-group"] = ff
+			root.plainLongFlags["tree-group"] = ff
+
+			ff = &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "help-search",
+					Description: "search the commands and flags by name, description, examples or env var",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+					Action:      helpSearch,
+					Examples: `
+$ {{.AppName}} --help-search deploy
+	list the commands and flags about 'deploy', the fuzzy matches are included
+`,
+				},
+				DefaultValue:            "",
+				DefaultValuePlaceholder: "TERM",
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["help-search"] = ff
+			root.plainLongFlags["help-search"] = ff
+
+			ff = &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "help-bindings",
+					Description: "show the env vars, config key and effective value of each flag in help screen",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+				},
+				DefaultValue: false,
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["help-bindings"] = ff
+			root.plainLongFlags["help-bindings"] = ff
 		}
 		if _, ok := root.allFlags[SysMgmtGroup]["config"]; !ok {
 			ff := &Flag{
@@ -270,6 +369,25 @@ $ {{.AppName}} --config=ci/etc/demo-yy/any.yml ~~debug
 			root.allFlags[SysMgmtGroup]["config"] = ff
 			root.plainLongFlags["config"] = ff
 		}
+		if _, ok := root.allFlags[SysMgmtGroup]["env-file"]; !ok {
+			ff := &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "env-file",
+					Description: "load the env vars from a dotenv file",
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+					Examples: `
+$ {{.AppName}} --env-file=deploy/prod.env ~~debug
+	load the settings such as 'APP_SERVER_PORT=8080' from 'deploy/prod.env'
+`,
+				},
+				DefaultValue:            "",
+				DefaultValuePlaceholder: "FILE",
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["env-file"] = ff
+			root.plainLongFlags["env-file"] = ff
+		}
 	}
 }
 
@@ -451,6 +569,70 @@ func (w *ExecWorker) attachCmdrCommands(root *RootCommand) {
 			root.allFlags[SysMgmtGroup]["no-color"] = ff
 			root.plainLongFlags["no-color"] = ff
 		}
+		if _, ok := root.allFlags[SysMgmtGroup]["no-pager"]; !ok {
+			ff := &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "no-pager",
+					Description: "Don't pipe the long outputs through $PAGER.",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+				},
+				DefaultValue: false,
+				EnvVars:      []string{"NOPAGER"},
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["no-pager"] = ff
+			root.plainLongFlags["no-pager"] = ff
+		}
+		if _, ok := root.allFlags[SysMgmtGroup]["locale"]; !ok {
+			ff := &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "locale",
+					Description: "The locale of help screen and messages, such as 'zh-CN'.",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+				},
+				DefaultValue:            "",
+				DefaultValuePlaceholder: "LOCALE",
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["locale"] = ff
+			root.plainLongFlags["locale"] = ff
+		}
+		if _, ok := root.allFlags[SysMgmtGroup]["lint"]; !ok {
+			ff := &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "lint",
+					Description: "Check the command tree for the conflicted names and the other mistakes.",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+					Action:      lint,
+				},
+				DefaultValue: false,
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["lint"] = ff
+			root.plainLongFlags["lint"] = ff
+		}
+		if _, ok := root.allFlags[SysMgmtGroup]["lint-examples"]; !ok {
+			ff := &Flag{
+				BaseOpt: BaseOpt{
+					Full:        "lint-examples",
+					Description: "Check the examples of all commands and flags against the command tree.",
+					Hidden:      true,
+					Group:       SysMgmtGroup,
+					owner:       &root.Command,
+					Action:      lintExamples,
+				},
+				DefaultValue: false,
+			}
+			root.Flags = append(root.Flags, ff)
+			root.allFlags[SysMgmtGroup]["lint-examples"] = ff
+			root.plainLongFlags["lint-examples"] = ff
+		}
 	}
 }
 
@@ -469,6 +651,26 @@ func (w *ExecWorker) attachGeneratorsCommands(root *RootCommand) {
 	}
 }
 
+func (w *ExecWorker) attachCompleteCommand(root *RootCommand) {
+	if w.enableGenerateCommands {
+		for _, sc := range root.SubCommands {
+			if sc.Full == completeCommandName {
+				return
+			}
+		}
+		root.SubCommands = append(root.SubCommands, &Command{
+			BaseOpt: BaseOpt{
+				Full:        completeCommandName,
+				Description: "return the completion candidates of the words, for the shell completion scripts.",
+				Hidden:      true,
+				Group:       SysMgmtGroup,
+				Action:      completeWords,
+			},
+			rawArgs: true,
+		})
+	}
+}
+
 func (w *ExecWorker) forFlagNames(flg *Flag, cmd *Command, singleFlagNames, stringFlagNames map[string]bool) {
 	if len(flg.Short) != 0 {
 		if _, ok := singleFlagNames[flg.Short]; ok {
@@ -581,20 +783,26 @@ func (w *ExecWorker) buildCrossRefs(cmd *Command) {
 
 	for _, flg := range cmd.Flags {
 		flg.owner = cmd
+		if _, removed := w.lifecycleOf(&flg.BaseOpt); removed {
+			flg.Hidden = true
+		}
 
 		w.buildCrossRefsForFlag(flg, cmd, singleFlagNames, stringFlagNames)
 
 		// opt.Children[flg.Full] = &OptOne{Value: flg.DefaultValue,}
-		w.rxxtOptions.Set(w.backtraceFlagNames(flg), flg.DefaultValue)
+		w.rxxtOptions.setFrom(w.backtraceFlagNames(flg), flg.DefaultValue, &ValueSource{Layer: SourceDefault})
 	}
 
 	for _, cx := range cmd.SubCommands {
 		cx.owner = cmd
+		if _, removed := w.lifecycleOf(&cx.BaseOpt); removed {
+			cx.Hidden = true
+		}
 
 		w.buildCrossRefsForCommand(cx, cmd, singleCmdNames, stringCmdNames)
 		// opt.Children[cx.Full] = newOpt()
 
-		w.rxxtOptions.Set(w.backtraceCmdNames(cx), nil)
+		w.rxxtOptions.setFrom(w.backtraceCmdNames(cx), nil, &ValueSource{Layer: SourceDefault})
 		// buildCrossRefs(cx, opt.Children[cx.Full])
 		w.buildCrossRefs(cx)
 	}
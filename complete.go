@@ -0,0 +1,173 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"strings"
+)
+
+// completeCommandName is the hidden command called by the shell
+// completion shims, see dynamicCompletion.
+const completeCommandName = "__complete"
+
+// The directives are printed as the last line of `__complete`, they
+// tell the shell what to do besides the candidates.
+const (
+	// completeDefault lets the shell complete the files if there are
+	// no candidates
+	completeDefault = "default"
+	// completeNoFiles completes the candidates only
+	completeNoFiles = "nofiles"
+	// completeFiles completes the files
+	completeFiles = "files"
+	// completeDirs completes the directories
+	completeDirs = "dirs"
+)
+
+// completeWords is the action of `__complete <words...>`, the last word
+// is the one being completed and may be empty. It prints a candidate
+// in each line as `value<TAB>description`, and a directive such as
+// `:files` in the last line.
+//
+// The words before the last one are parsed by a dry-run with the
+// config files loaded, so that the current command, the flags used and
+// the config entries are known to the completion callbacks, see
+// Flag.Completion and Command.Completion.
+func completeWords(cmd *Command, args []string) (err error) {
+	root := internalGetWorker().rootCommand
+	words := append([]string{}, args...)
+	if len(words) == 0 {
+		words = append(words, "")
+	}
+	cur, prev := words[len(words)-1], words[:len(words)-1]
+
+	var candidates []string
+	var directive string
+	complete := func(last *Command) {
+		candidates, directive = completeFor(last, cur)
+	}
+
+	// a value flag followed by the current word: complete its value
+	if n := len(prev); n > 0 && strings.HasPrefix(prev[n-1], "-") && !strings.Contains(prev[n-1], "=") {
+		_ = dryRun(root, append([]string{root.AppName}, prev[:n-1]...), true, func(last *Command) {
+			if flg := completeLookupFlag(last, prev[n-1]); flg != nil && shellFlagTakesValue(flg) {
+				candidates, directive = completeFlagValue(flg, "", cur)
+			} else {
+				complete(last)
+			}
+		})
+	} else {
+		_ = dryRun(root, append([]string{root.AppName}, prev...), true, complete)
+	}
+
+	for _, c := range candidates {
+		fp("%v", c)
+	}
+	fp(":%v", directive)
+	return
+}
+
+// completeFor returns the candidates of the current word under the
+// command last.
+func completeFor(last *Command, cur string) (candidates []string, directive string) {
+	if strings.HasPrefix(cur, "-") {
+		if ix := strings.Index(cur, "="); ix > 0 {
+			if flg := completeLookupFlag(last, cur[:ix]); flg != nil && shellFlagTakesValue(flg) {
+				return completeFlagValue(flg, cur[:ix+1], cur[ix+1:])
+			}
+			return nil, completeNoFiles
+		}
+		return completeFlags(last, cur), completeNoFiles
+	}
+
+	var subs []*Command
+	for _, cc := range last.SubCommands {
+		if !cc.Hidden {
+			subs = append(subs, cc)
+		}
+	}
+	if len(subs) > 0 {
+		for _, cc := range subs {
+			for _, n := range cc.GetTitleNamesArray() {
+				if strings.HasPrefix(n, cur) {
+					candidates = append(candidates, n+"\t"+shellDesc(&cc.BaseOpt))
+				}
+			}
+		}
+		return candidates, completeNoFiles
+	}
+
+	if last.Completion != nil {
+		return last.Completion(cur), completeNoFiles
+	}
+	return nil, completeDefault
+}
+
+// completeFlags returns the names of the visible flags of cmd and its
+// parents, which start with prefix.
+func completeFlags(cmd *Command, prefix string) (candidates []string) {
+	for c := cmd; c != nil; c = c.owner {
+		for _, flg := range c.Flags {
+			if flg.Hidden {
+				continue
+			}
+			var names []string
+			for _, n := range flg.GetShortTitleNamesArray() {
+				names = append(names, "-"+n)
+			}
+			for _, n := range flg.GetLongTitleNamesArray() {
+				names = append(names, "--"+n)
+			}
+			for _, n := range names {
+				if strings.HasPrefix(n, prefix) {
+					candidates = append(candidates, n+"\t"+shellDesc(&flg.BaseOpt))
+				}
+			}
+		}
+	}
+	return
+}
+
+// completeFlagValue returns the candidates of the value of flg, the
+// head is prepended to each candidate, for the form `--flag=value`.
+func completeFlagValue(flg *Flag, head, prefix string) (candidates []string, directive string) {
+	var values []string
+	for _, v := range flg.ValidArgs {
+		if strings.HasPrefix(v, prefix) {
+			values = append(values, v)
+		}
+	}
+	if flg.Completion != nil {
+		values = uniAddStrs(values, flg.Completion(prefix)...)
+	}
+	for _, v := range values {
+		candidates = append(candidates, head+v)
+	}
+
+	switch {
+	case len(flg.ValidArgs) > 0 || flg.Completion != nil:
+		directive = completeNoFiles
+	case shellFlagPathKind(flg) == "dir":
+		directive = completeDirs
+	case shellFlagPathKind(flg) == "file":
+		directive = completeFiles
+	default:
+		directive = completeDefault
+	}
+	return
+}
+
+// completeLookupFlag finds the flag named by word, such as `-p` or
+// `--port`, in cmd and its parents.
+func completeLookupFlag(cmd *Command, word string) *Flag {
+	for c := cmd; c != nil; c = c.owner {
+		if strings.HasPrefix(word, "--") {
+			if flg, ok := c.plainLongFlags[word[2:]]; ok {
+				return flg
+			}
+		} else if flg, ok := c.plainShortFlags[strings.TrimPrefix(word, "-")]; ok {
+			return flg
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestCompleteWords(t *testing.T) {
+	defer resetOsArgs()
+
+	buildRoot := func() *cmdr.RootCommand {
+		root := cmdr.Root("comp-app", "1.0.0")
+		server := root.NewSubCommand("server", "s").Description("server operations", "")
+		server.NewSubCommand("start", "st").Description("start the server", "").
+			Completion(func(prefix string) []string {
+				// the flags before the current word are parsed already
+				ns := cmdr.GetStringR("server.namespace")
+				return []string{ns + "-web", ns + "-db"}
+			})
+		server.NewSubCommand("stop").Description("stop the server", "")
+		server.NewFlagV("default", "namespace", "n").Description("the namespace", "").
+			Completion(func(prefix string) []string { return []string{"default", "kube-system"} })
+		server.NewFlagV("info", "level").Description("the log level", "").ValidArgs("debug", "info", "warn")
+		server.NewFlagV("", "work-dir", "w").Description("the working directory", "")
+		server.NewFlagV(8080, "port", "p").Description("the listening port", "")
+		root.NewSubCommand("secret").Hidden(true)
+		return root.RootCommand()
+	}
+
+	for _, c := range []struct {
+		words  []string
+		expect string
+	}{
+		{[]string{"s"}, "s\tserver operations\nserver\tserver operations\n:nofiles\n"},
+		{[]string{"server", "st"}, "st\tstart the server\nstart\tstart the server\nstop\tstop the server\n:nofiles\n"},
+		{[]string{"server", "--le"}, "--level\tthe log level\n:nofiles\n"},
+		{[]string{"server", "--level", ""}, "debug\ninfo\nwarn\n:nofiles\n"},
+		{[]string{"server", "--level=w"}, "--level=warn\n:nofiles\n"},
+		{[]string{"server", "-n", ""}, "default\nkube-system\n:nofiles\n"},
+		{[]string{"server", "-n", "prod", "start", ""}, "prod-web\nprod-db\n:nofiles\n"},
+		{[]string{"server", "--port", "80", "-w", ""}, ":dirs\n"},
+		{[]string{"server", "stop", ""}, ":default\n"},
+		{[]string{"sec"}, ":nofiles\n"},
+	} {
+		cmdr.ResetOptions()
+		cmdr.InternalResetWorker()
+		outX, _ := prepareStreams()
+
+		os.Args = append([]string{"comp-app", "__complete"}, c.words...)
+		if err := cmdr.Exec(buildRoot(), cmdr.WithNoLoadConfigFiles(true)); err != nil {
+			t.Fatal(err)
+		}
+		cmdr.InternalResetWorker() // flush the outputs
+
+		if out := outX.String(); out != c.expect {
+			t.Fatalf("%q: expecting %q but got %q", c.words, c.expect, out)
+		}
+	}
+	cmdr.ResetOptions()
+}
+
+func TestCompleteWithConfig(t *testing.T) {
+	defer resetOsArgs()
+
+	dir, err := ioutil.TempDir("", "cmdr-complete")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	_ = ioutil.WriteFile(path.Join(dir, "comp-app.yml"), []byte("comp:\n  server:\n    endpoint: https://api.local\n"), 0644)
+
+	root := cmdr.Root("comp-app", "1.0.0")
+	server := root.NewSubCommand("server", "s")
+	server.NewFlagV("", "endpoint")
+	server.NewSubCommand("deploy").
+		Completion(func(prefix string) []string {
+			// the endpoint from the config file, under the prefix of the app
+			return []string{cmdr.GetStringR("server.endpoint") + "/svc-a"}
+		})
+
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+	outX, _ := prepareStreams()
+
+	os.Args = []string{"comp-app", "__complete", "server", "deploy", ""}
+	if err = cmdr.Exec(root.RootCommand(), cmdr.WithRxxtPrefix("comp"),
+		cmdr.WithPredefinedLocations(dir+"/%s.yml"), cmdr.WithNoWatchConfigFiles(true)); err != nil {
+		t.Fatal(err)
+	}
+	cmdr.InternalResetWorker() // flush the outputs
+
+	if expect, out := "https://api.local/svc-a\n:nofiles\n", outX.String(); out != expect {
+		t.Fatalf("expecting %q but got %q", expect, out)
+	}
+	cmdr.ResetOptions()
+}
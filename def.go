@@ -52,6 +52,9 @@ type (
 
 		// Deprecated is a version string just like '0.5.9' or 'v0.5.9', that means this command/flag was/will be deprecated since `v0.5.9`.
 		Deprecated string
+		// RemovedIn is a version string, since which this command/flag is
+		// hidden and reports an error if it's used.
+		RemovedIn string
 
 		// Action is callback for the last recognized command/sub-command.
 		// return: ErrShouldBeStopException will break the following flow and exit right now
@@ -76,6 +79,10 @@ type (
 		// TailArgsText string
 		// TailArgsDesc string
 
+		// Completion returns the candidates of the positional arguments
+		// for the shell completion, the prefix is the word being typed.
+		Completion func(prefix string) []string
+
 		root            *RootCommand
 		allCmds         map[string]map[string]*Command // key1: Commnad.Group, key2: Command.Full
 		allFlags        map[string]map[string]*Flag    // key1: Command.Flags[#].Group, key2: Command.Flags[#].Fullui
@@ -83,6 +90,10 @@ type (
 		plainShortFlags map[string]*Flag
 		plainLongFlags  map[string]*Flag
 		headLikeFlag    *Flag
+
+		// rawArgs stops the parsing, the args after this command are
+		// passed to its Action as is
+		rawArgs bool
 	}
 
 	// RootCommand holds some application information
@@ -122,6 +133,16 @@ type (
 		// It's an environment variable name, such as: "EDITOR" (or cmdr.ExternalToolEditor)
 		ExternalTool string
 
+		// ReplacedBy forwards this flag to the new one, the value of this
+		// flag will be set to ReplacedBy and its Action will be invoked.
+		// It's used with Deprecated typically.
+		ReplacedBy *Flag
+
+		// Completion returns the candidates of the value for the shell
+		// completion, the prefix is the word being typed. The ValidArgs
+		// are completed too.
+		Completion func(prefix string) []string
+
 		// EnvVars give a list to bind to environment variables manually
 		// it'll take effects since v1.6.9
 		EnvVars []string
@@ -164,6 +185,20 @@ type (
 		usedConfigFile   string
 		usedConfigSubDir string
 		configFiles      []string
+		// iniComments holds the comments of the loaded ini files by the
+		// key paths, for SaveAsIni
+		iniComments map[string][]string
+		// dotenv holds the variables of the loaded dotenv files
+		dotenv map[string]string
+		// sources are the loaded yaml config files by the file paths,
+		// and keyOrigins are the files where the keys came from, for
+		// SaveConfigChanges
+		sources    map[string]*configSource
+		keyOrigins map[string]string
+		// origins are the sources of the values by the keys, see
+		// GetSource
+		origins       map[string]ValueSource
+		dotenvSources map[string]ValueSource
 
 		onConfigReloadedFunctions map[ConfigReloaded]bool
 		rwlCfgReload              *sync.RWMutex
@@ -224,6 +259,8 @@ var (
 	CurrentDefaultValueColor = FgDarkGray
 	// CurrentGroupTitleColor the print color for titles
 	CurrentGroupTitleColor = DarkColor
+	// CurrentHighlightColor the print color for the matched words of --help-search
+	CurrentHighlightColor = FgLightYellow
 
 	// globalShowVersion   func()
 	// globalShowBuildInfo func()
@@ -266,9 +303,11 @@ func GetQuietMode() bool {
 	return GetBoolR("quiet")
 }
 
-// GetNoColorMode return the flag value of `--no-color`
+// GetNoColorMode return the flag value of `--no-color`, or true if the
+// stdout isn't a terminal, or the env var NO_COLOR is set, see also
+// CLICOLOR and CLICOLOR_FORCE.
 func GetNoColorMode() bool {
-	return GetBoolR("no-color")
+	return GetBoolR("no-color") || internalGetWorker().noColorStdout
 }
 
 // func init() {
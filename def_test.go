@@ -6,11 +6,14 @@ package cmdr_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"fmt"
 	"github.com/hedzr/cmdr"
 	"github.com/hedzr/logex"
 	"gopkg.in/hedzr/errors.v2"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -394,14 +397,22 @@ func TestForGenerateCommands(t *testing.T) {
 	cmdr.ResetOptions()
 	cmdr.Set("no-watch-conf-dir", true)
 
+	manDir, err := ioutil.TempDir("", "cmdr-man")
+	if err != nil {
+		t.Fatal(err)
+	}
 	defer func() {
 		_ = os.Remove(".tmp.1.json")
 		_ = os.Remove(".tmp.1.yaml")
 		_ = os.Remove(".tmp.1.toml")
+		_ = os.RemoveAll(manDir)
 	}()
 
 	var commands = []string{
+		"consul-tags gen man --dir " + manDir,
+		"consul-tags gen man --single --gzip --dir " + manDir,
 		"consul-tags gen doc --markdown",
+		"consul-tags gen doc --single-page",
 		"consul-tags gen shell --auto",
 		"consul-tags gen shell --auto --force-bash",
 		"consul-tags gen doc",
@@ -414,6 +425,8 @@ func TestForGenerateCommands(t *testing.T) {
 		"consul-tags gen doc --tex",
 		"consul-tags gen doc --doc",
 		"consul-tags gen doc --docx",
+		"consul-tags gen doc --html",
+		"consul-tags gen html --single-page",
 		"consul-tags gen shell --bash",
 		"consul-tags gen shell --zsh",
 		"consul-tags gen shell",
@@ -428,6 +441,10 @@ func TestForGenerateCommands(t *testing.T) {
 		cmdr.Set("generate.doc.tex", false)
 		cmdr.Set("generate.doc.doc", false)
 		cmdr.Set("generate.doc.docx", false)
+		cmdr.Set("generate.doc.html", false)
+		cmdr.Set("generate.doc.single-page", false)
+		cmdr.Set("generate.manual.single", false)
+		cmdr.Set("generate.manual.gzip", false)
 
 		os.Args = strings.Split(cc, " ")
 		fmt.Printf("  . args = [%v], go ...\n", os.Args)
@@ -437,11 +454,49 @@ func TestForGenerateCommands(t *testing.T) {
 		}
 		// time.Sleep(time.Second)
 	}
+	resetManualFlags()
+
+	gzs, _ := filepath.Glob(filepath.Join(manDir, "*.1.gz"))
+	if len(gzs) != 1 {
+		t.Fatalf("expect one gzipped man page but got %v", gzs)
+	}
+	f, err := os.Open(gzs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, err := ioutil.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sec := range []string{".SH SUBCOMMANDS", ".SH ENVIRONMENT", ".SH FILES"} {
+		if !bytes.Contains(page, []byte(sec)) {
+			t.Fatalf("expect the section %q in the single man page", sec)
+		}
+	}
 
 	resetOsArgs()
 	cmdr.ResetOptions()
 }
 
+// resetManualFlags resets the toggles of 'gen man', the parsed values
+// are kept in the flags of the shared generator commands.
+func resetManualFlags() {
+	man := cmdr.FindSubCommandRecursive("manual", nil)
+	for _, name := range []string{"single", "gzip"} {
+		cmdr.Set("generate.manual."+name, false)
+		if man != nil {
+			if f := man.FindFlag(name); f != nil {
+				f.DefaultValue = false
+			}
+		}
+	}
+}
+
 func TestForGenerateDoc(t *testing.T) {
 	copyRootCmd = rootCmdForTesting
 
@@ -476,8 +531,8 @@ func TestForGenerateMan(t *testing.T) {
 	cmdr.Set("no-watch-conf-dir", true)
 
 	defer func() {
-		_ = os.Remove("man1")
-		_ = os.Remove("man3")
+		_ = cmdr.RemoveDirRecursive("man1")
+		_ = cmdr.RemoveDirRecursive("man3")
 	}()
 
 	var commands = []string{
@@ -0,0 +1,38 @@
+// Copyright © 2020 Hedzr Yeh.
+
+// cmdr-gen is a tiny wrapper of the builtin generators of cmdr, it's
+// useful for go:generate:
+//
+//     //go:generate go run github.com/hedzr/cmdr/examples/cmdr-gen gen code --spec cli.yml
+//
+package main
+
+import (
+	"fmt"
+	"github.com/hedzr/cmdr"
+	"os"
+)
+
+func main() {
+	if err := cmdr.Exec(rootCmd,
+		cmdr.WithBuiltinCommands(true, true, false, true, true),
+		cmdr.WithNoLoadConfigFiles(true),
+	); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+var rootCmd = &cmdr.RootCommand{
+	Command: cmdr.Command{
+		BaseOpt: cmdr.BaseOpt{
+			Name: "cmdr-gen",
+		},
+	},
+
+	AppName:    "cmdr-gen",
+	Version:    cmdr.Version,
+	VersionInt: cmdr.VersionInt,
+	Copyright:  "cmdr-gen is the generators of cmdr",
+	Author:     "Hedzr Yeh <hedzrz@gmail.com>",
+}
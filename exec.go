@@ -66,7 +66,19 @@ type ExecWorker struct {
 
 	envvarToValueMap map[string]func() string
 
-	helpTailLine string
+	helpTailLine     string
+	helpFlagBindings bool
+	noPager          bool
+	paging           bool
+	customStdout     bool
+	noColorStdout    bool
+	noColorStderr    bool
+	theme            string
+	dryRun           bool
+	treeRequested    bool
+	dryRunIssues     []string
+	dryRunCommand    *Command
+	locale           string
 
 	onSwitchCharHit   func(parsed *Command, switchChar string, args []string) (err error)
 	onPassThruCharHit func(parsed *Command, switchChar string, args []string) (err error)
@@ -172,6 +184,7 @@ func (w *ExecWorker) InternalExecFor(rootCmd *RootCommand, args []string) (last
 		stop      bool
 		matched   bool
 	)
+	w.treeRequested = false
 
 	if w.rootCommand == nil {
 		w.setupRootCommand(rootCmd)
@@ -213,6 +226,9 @@ func (w *ExecWorker) InternalExecFor(rootCmd *RootCommand, args []string) (last
 			//  - -n'consul', -n"consul" could works too.
 			// -t3: opt with an argument.
 			matched, stop, err = w.xxTestCmd(pkg, &goCommand, rootCmd, args)
+			if _, ok := err.(*removedError); ok {
+				return
+			}
 			if e, ok := err.(*ErrorForCmdr); ok {
 				ferr("%v", e)
 				if !e.Ignorable {
@@ -307,6 +323,11 @@ func (w *ExecWorker) preprocess(rootCmd *RootCommand, args []string) (err error)
 		err = w.rxxtOptions.buildAutomaticEnv(rootCmd)
 	}
 
+	if err == nil {
+		w.detectColors()
+		err = w.applyTheme()
+	}
+
 	w.rxxtOptions.setCB(w.onOptionMergingSet, w.onOptionSet)
 
 	if err == nil {
@@ -319,6 +340,13 @@ func (w *ExecWorker) preprocess(rootCmd *RootCommand, args []string) (err error)
 
 func (w *ExecWorker) afterInternalExec(pkg *ptpkg, rootCmd *RootCommand, goCommand *Command, args []string) (err error) {
 	w.checkState(pkg)
+	w.dryRunCommand = goCommand
+
+	if w.treeRequested && !w.dryRun {
+		// --tree is handled after parsing, so that it sees the --tree-*
+		// flags and the command it follows
+		return dumpTree(goCommand)
+	}
 
 	if !pkg.needHelp && len(pkg.unknownCmds) == 0 && len(pkg.unknownFlags) == 0 {
 		if goCommand.Action != nil {
@@ -352,6 +380,9 @@ func (w *ExecWorker) afterInternalExec(pkg *ptpkg, rootCmd *RootCommand, goComma
 }
 
 func (w *ExecWorker) ainvk(pkg *ptpkg, rootCmd *RootCommand, goCommand *Command, args []string) (err error) {
+	if w.dryRun {
+		return
+	}
 	if goCommand != &rootCmd.Command {
 		if w.noCommandAction {
 			return
@@ -7,8 +7,22 @@ package cmdr
 func (w *ExecWorker) cmdMatching(pkg *ptpkg, goCommand **Command, args []string) (matched, stop bool, err error) {
 	// command, files
 	if cmd, ok := (*goCommand).plainCmds[pkg.a]; ok {
+		if err = w.checkCommandLifecycle(cmd); err != nil {
+			stop = true
+			return
+		}
 		cmd.strHit = pkg.a
 		*goCommand = cmd
+		if cmd.rawArgs {
+			// the remained args are passed to the action without
+			// parsing, and matched is false so the action will not be
+			// invoked again by afterInternalExec.
+			stop = true
+			if !w.dryRun && cmd.Action != nil {
+				err = cmd.Action(cmd, w.getArgs(pkg, args))
+			}
+			return
+		}
 		matched = true
 		// logrus.Debugf("-- command '%v' hit, go ahead...", cmd.GetTitleName())
 		stop, err = w.cmdMatched(pkg, *goCommand, args)
@@ -27,7 +41,7 @@ func (w *ExecWorker) cmdMatching(pkg *ptpkg, goCommand **Command, args []string)
 }
 
 func (w *ExecWorker) cmdMatched(pkg *ptpkg, goCommand *Command, args []string) (stop bool, err error) {
-	if goCommand.PreAction != nil {
+	if goCommand.PreAction != nil && !w.dryRun {
 		if err = goCommand.PreAction(goCommand, w.getArgs(pkg, args)); err == ErrShouldBeStopException {
 			return false, nil
 		}
@@ -145,6 +159,14 @@ GO_UP:
 }
 
 func (w *ExecWorker) flagsMatched(pkg *ptpkg, goCommand *Command, args []string) (upLevel, stop bool, err error) {
+	if err = w.checkFlagLifecycle(pkg.flg); err != nil {
+		stop = true
+		return
+	}
+	if pkg.flg.ReplacedBy != nil {
+		pkg.flg.times++
+		pkg.flg = pkg.flg.ReplacedBy
+	}
 	pkg.flg.times++
 
 	if err = pkg.tryExtractingValue(args); err != nil {
@@ -156,14 +178,18 @@ func (w *ExecWorker) flagsMatched(pkg *ptpkg, goCommand *Command, args []string)
 		// if !GetBoolP(getPrefix(), "quiet") {
 		// 	logrus.Debugf("-- flag '%v' hit, go ahead...", pkg.flg.GetTitleName())
 		// }
-		if pkg.flg.Action != nil {
+		if pkg.flg.Action != nil && !w.dryRun {
 			if err = pkg.flg.Action(goCommand, w.getArgs(pkg, args)); err == ErrShouldBeStopException {
 				stop = true
 				err = nil
 				return
+			} else if _, ok := err.(*ValidationError); ok {
+				// ~~lint fails the Exec() for CI
+				stop = true
+				return
 			}
 		}
-		if isBool(pkg.flg.DefaultValue) || isNil1(pkg.flg.DefaultValue) {
+		if (isBool(pkg.flg.DefaultValue) || isNil1(pkg.flg.DefaultValue)) && !w.dryRun {
 			pkg.tryToggleGroup()
 		}
 
@@ -22,11 +22,19 @@ var (
 // }
 
 func unknownCommand(pkg *ptpkg, cmd *Command, args []string) {
+	if w := internalGetWorker(); w.dryRun {
+		w.dryRunIssues = append(w.dryRunIssues, T("unknown command %q", pkg.a))
+		return
+	}
 	if internalGetWorker().noUnknownCmdTip {
 		return
 	}
 
-	ferr("\n\x1b[%dmUnknown command:\x1b[0m %v", BgBoldOrBright, pkg.a)
+	if GetNoColorMode() || internalGetWorker().noColorStderr {
+		ferr("\n%v %v", T("Unknown command:"), pkg.a)
+	} else {
+		ferr("\n\x1b[%dm%v\x1b[0m %v", BgBoldOrBright, T("Unknown command:"), pkg.a)
+	}
 	if unknownOptionHandler != nil {
 		if !unknownOptionHandler(false, pkg.a, cmd, args) {
 			return
@@ -36,11 +44,19 @@ func unknownCommand(pkg *ptpkg, cmd *Command, args []string) {
 }
 
 func unknownFlag(pkg *ptpkg, cmd *Command, args []string) {
+	if w := internalGetWorker(); w.dryRun {
+		w.dryRunIssues = append(w.dryRunIssues, T("unknown flag %q", pkg.a))
+		return
+	}
 	if internalGetWorker().noUnknownCmdTip {
 		return
 	}
 
-	ferr("\n\x1b[%dmUnknown flag:\x1b[0m %v", BgBoldOrBright, pkg.a)
+	if GetNoColorMode() || internalGetWorker().noColorStderr {
+		ferr("\n%v %v", T("Unknown flag:"), pkg.a)
+	} else {
+		ferr("\n\x1b[%dm%v\x1b[0m %v", BgBoldOrBright, T("Unknown flag:"), pkg.a)
+	}
 	if unknownOptionHandler != nil && !pkg.short {
 		if !unknownOptionHandler(true, pkg.a, cmd, args) {
 			return
@@ -54,7 +70,7 @@ func unknownCommandDetector(pkg *ptpkg, cmd *Command, args []string) {
 	for k := range cmd.plainCmds {
 		distance := float64(defaultStringMetric.Calc(pkg.a, k)) / stringMetricFactor
 		if distance >= internalGetWorker().similarThreshold {
-			ferr("  - do you mean: %v", k)
+			ferr("  - %v", T("do you mean: %v", k))
 			ever = true
 		}
 	}
@@ -83,7 +99,7 @@ func unknownFlagDetector(pkg *ptpkg, cmd *Command, args []string) {
 		for k := range cmd.plainLongFlags {
 			distance := float64(defaultStringMetric.Calc(str, k)) / stringMetricFactor
 			if distance >= internalGetWorker().similarThreshold {
-				ferr("  - do you mean: --%v", k)
+				ferr("  - %v", T("do you mean: %v", "--"+k))
 				ever = true
 				// } else {
 				// 	ferr("  ? '%v' - '%v': %v", pkg.a, k, distance)
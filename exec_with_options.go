@@ -216,6 +216,7 @@ func WithInternalOutputStreams(out, err *bufio.Writer) ExecOption {
 	return func(w *ExecWorker) {
 		w.defaultStdout = out
 		w.defaultStderr = err
+		w.customStdout = out != nil
 
 		if w.defaultStdout == nil {
 			w.defaultStdout = bufio.NewWriterSize(os.Stdout, 16384)
@@ -288,6 +289,15 @@ func WithNoColor(b bool) ExecOption {
 	}
 }
 
+// WithTheme selects a color theme by name, such as "default", "dark",
+// "light" or the one registered by RegisterTheme. The config entry
+// `app.theme.name` overrides it.
+func WithTheme(name string) ExecOption {
+	return func(w *ExecWorker) {
+		w.theme = name
+	}
+}
+
 // WithNoEnvOverrides enables the internal no-env-overrides mode
 //
 // Since v1.6.2+
@@ -347,6 +357,37 @@ func WithHelpTailLine(line string) ExecOption {
 	}
 }
 
+// WithHelpFlagBindings shows the bound env vars, the config key path
+// and the effective value with its source for each flag, in the help
+// screen and the generated man pages and markdown documents.
+//
+// It can be enabled by `--help-bindings` at runtime too.
+func WithHelpFlagBindings(b bool) ExecOption {
+	return func(w *ExecWorker) {
+		w.helpFlagBindings = b
+	}
+}
+
+// WithNoPager disables the pager for the long help screen and the
+// other long outputs, see Paged().
+func WithNoPager(b bool) ExecOption {
+	return func(w *ExecWorker) {
+		w.noPager = b
+	}
+}
+
+// WithLocale setup the locale of the help screen, the generated
+// documents and the error messages, such as "zh-CN".
+//
+// The `--locale` flag, the config entry `app.locale` and the
+// environment variables LC_ALL, LC_MESSAGES and LANG can be used too,
+// see also GetLocale() and AddTranslations().
+func WithLocale(locale string) ExecOption {
+	return func(w *ExecWorker) {
+		w.locale = locale
+	}
+}
+
 // WithUnhandledErrorHandler handle the panics or exceptions generally
 func WithUnhandledErrorHandler(handler UnhandledErrorHandler) ExecOption {
 	return func(w *ExecWorker) {
@@ -63,6 +63,26 @@ func ResetRootInWorker() {
 	internalGetWorker().rootCommand = nil
 }
 
+// SaveTranslations takes a copy of the message catalog, and returns a
+// func to restore it
+func SaveTranslations() (restore func()) {
+	translationsRW.RLock()
+	saved := make(map[string]map[string]string, len(translations))
+	for locale, m := range translations {
+		saved[locale] = make(map[string]string, len(m))
+		for k, v := range m {
+			saved[locale][k] = v
+		}
+	}
+	translationsRW.RUnlock()
+
+	return func() {
+		translationsRW.Lock()
+		translations = saved
+		translationsRW.Unlock()
+	}
+}
+
 func TestEmptyUnknownOptionHandler(t *testing.T) {
 	emptyUnknownOptionHandler(false, "", nil, nil)
 }
@@ -367,6 +387,7 @@ func SetInternalOutputStreams(out, err *bufio.Writer) {
 	w := internalGetWorker()
 	w.defaultStdout = out
 	w.defaultStderr = err
+	w.customStdout = out != nil
 
 	if w.defaultStdout == nil {
 		w.defaultStdout = bufio.NewWriterSize(os.Stdout, 16384)
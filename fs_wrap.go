@@ -10,9 +10,9 @@ package cmdr
 import (
 	"bufio"
 	"github.com/fsnotify/fsnotify"
+	"io/ioutil"
 	"log"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -76,9 +76,11 @@ func fsWatchRunner(s *Options, configDir string, filesWatching []string, watcher
 							if err != nil {
 								log.Printf("ERROR: os.Open() returned %v\n", err)
 							} else {
-								err = s.mergeConfigFile(bufio.NewReader(file), path.Ext(event.Name))
+								err = s.mergeConfigFile(bufio.NewReader(file), event.Name)
 								if err != nil {
 									log.Printf("ERROR: os.Open() returned %v\n", err)
+								} else if b, e := ioutil.ReadFile(event.Name); e == nil {
+									_ = s.trackConfigFile(event.Name, b)
 								}
 								s.reloadConfig()
 								_ = file.Close()
@@ -19,18 +19,37 @@ var (
 - linux man page generator
 - shell completion script generator
 - markdown generator
+- go source generator from a command spec (yaml/json)
 - more...
 
 			`,
 			Examples: `
 $ {{.AppName}} gen sh --bash
 			generate bash completion script
+$ {{.AppName}} gen sh --zsh > ~/.zsh/completions/_{{.AppName}}
+			generate zsh completion function, and put it into $fpath
+$ {{.AppName}} gen sh --fish > ~/.config/fish/completions/{{.AppName}}.fish
+			generate fish completion script
+$ {{.AppName}} gen sh --powershell | Out-String | Invoke-Expression
+			enable the completion in PowerShell
+$ {{.AppName}} gen sh --bash --dynamic
+			generate bash completion script which calls the app for the candidates
 $ {{.AppName}} gen shell --auto
 			generate shell completion script with detecting on current shell environment.
+$ {{.AppName}} gen shell --install
+			install the completion script of the current shell for the current user
+$ {{.AppName}} gen shell --zsh --install --dir ./pkg/zsh/site-functions
+			write the zsh completion script into a directory for packaging
+$ {{.AppName}} gen shell --uninstall --dry-run
+			show which completion script would be removed
 $ {{.AppName}} gen sh
 			generate shell completion script with detecting on current shell environment.
 $ {{.AppName}} gen man
 			generate linux manual (man page)
+$ {{.AppName}} gen man --single --gzip
+			generate one compressed man page for all commands
+$ {{.AppName}} gen man --locale zh-CN --dir man/zh_CN/man1
+			generate the man pages in simplified chinese
 $ {{.AppName}} gen doc
 			generate document, default markdown.
 $ {{.AppName}} gen doc --markdown
@@ -41,6 +60,10 @@ $ {{.AppName}} gen markdown
 			generate markdown.
 $ {{.AppName}} gen pdf
 			generate pdf.
+$ {{.AppName}} gen doc --html --single-page
+			generate a static html site in one page.
+$ {{.AppName}} gen code --spec cli.yml --dir ./cli
+			generate the go source codes of a command tree from a spec file.
 			`,
 		},
 		SubCommands: []*Command{{
@@ -48,7 +71,7 @@ $ {{.AppName}} gen pdf
 				Short:       "s",
 				Full:        "shell",
 				Aliases:     []string{"sh"},
-				Description: "generate the bash/zsh auto-completion script or install it.",
+				Description: "generate the bash/zsh/fish/powershell auto-completion script or install it.",
 				Action:      genShell,
 			},
 			Flags: []*Flag{
@@ -60,6 +83,7 @@ $ {{.AppName}} gen pdf
 						Description: "generate auto completion script for Bash",
 					},
 					DefaultValue: false,
+					ToggleGroup:  "shell",
 				},
 				{
 					BaseOpt: BaseOpt{
@@ -69,6 +93,26 @@ $ {{.AppName}} gen pdf
 						Description: "generate auto completion script for Zsh",
 					},
 					DefaultValue: false,
+					ToggleGroup:  "shell",
+				},
+				{
+					BaseOpt: BaseOpt{
+						Full:        "fish",
+						Group:       "shell",
+						Description: "generate auto completion script for Fish",
+					},
+					DefaultValue: false,
+					ToggleGroup:  "shell",
+				},
+				{
+					BaseOpt: BaseOpt{
+						Full:        "powershell",
+						Aliases:     []string{"pwsh"},
+						Group:       "shell",
+						Description: "generate auto completion script for PowerShell",
+					},
+					DefaultValue: false,
+					ToggleGroup:  "shell",
 				},
 				{
 					BaseOpt: BaseOpt{
@@ -78,6 +122,49 @@ $ {{.AppName}} gen pdf
 						Description: "generate auto completion script to fit for your current env.",
 					},
 					DefaultValue: true,
+					ToggleGroup:  "shell",
+				},
+				{
+					BaseOpt: BaseOpt{
+						Full:        "dynamic",
+						Description: "generate a script which completes by calling the app, for the dynamic values",
+					},
+					DefaultValue: false,
+				},
+				{
+					BaseOpt: BaseOpt{
+						Short:       "i",
+						Full:        "install",
+						Group:       "install",
+						Description: "install the completion script for the current user",
+					},
+					DefaultValue: false,
+				},
+				{
+					BaseOpt: BaseOpt{
+						Full:        "uninstall",
+						Group:       "install",
+						Description: "remove the installed completion script",
+					},
+					DefaultValue: false,
+				},
+				{
+					BaseOpt: BaseOpt{
+						Full:        "dry-run",
+						Group:       "install",
+						Description: "show what would be changed by --install or --uninstall",
+					},
+					DefaultValue: false,
+				},
+				{
+					BaseOpt: BaseOpt{
+						Short:       "d",
+						Full:        "dir",
+						Group:       "install",
+						Description: "install into this directory instead of the user-level one, for packaging",
+					},
+					DefaultValue:            "",
+					DefaultValuePlaceholder: "DIR",
 				},
 				{
 					BaseOpt: BaseOpt{
@@ -109,13 +196,31 @@ $ {{.AppName}} gen pdf
 					DefaultValue:            "./man1",
 					DefaultValuePlaceholder: "DIR",
 				},
+				{
+					BaseOpt: BaseOpt{
+						Short:       "1",
+						Full:        "single",
+						Aliases:     []string{"one-file"},
+						Description: "generate one man page for all commands",
+					},
+					DefaultValue: false,
+				},
+				{
+					BaseOpt: BaseOpt{
+						Short:       "z",
+						Full:        "gzip",
+						Aliases:     []string{"gz"},
+						Description: "compress the man pages with gzip (.1.gz)",
+					},
+					DefaultValue: false,
+				},
 			},
 		}, {
 			BaseOpt: BaseOpt{
 				Short:       "d",
 				Full:        "doc",
-				Aliases:     []string{"markdown", "pdf", "docx", "tex"},
-				Description: "generate a markdown document, or: pdf/TeX/...",
+				Aliases:     []string{"markdown", "pdf", "docx", "tex", "html"},
+				Description: "generate a markdown document, or: pdf/TeX/html/...",
 				Action:      genDoc,
 			},
 			Flags: []*Flag{
@@ -160,7 +265,25 @@ $ {{.AppName}} gen pdf
 					BaseOpt: BaseOpt{
 						Full:        "docx",
 						Group:       "doc",
-						Description: "generate word docx",
+						Description: "generate a word document (.docx)",
+					},
+					DefaultValue: false,
+				},
+				{
+					BaseOpt: BaseOpt{
+						Full:        "html",
+						Aliases:     []string{"htm"},
+						Group:       "doc",
+						Description: "generate a static html site",
+					},
+					DefaultValue: false,
+				},
+				{
+					BaseOpt: BaseOpt{
+						Full:        "single-page",
+						Aliases:     []string{"one-page"},
+						Group:       "output",
+						Description: "generate all commands into one page, for --html and --markdown",
 					},
 					DefaultValue: false,
 				},
@@ -169,9 +292,9 @@ $ {{.AppName}} gen pdf
 						Short:       "t",
 						Full:        "tex",
 						Group:       "doc",
-						Description: "generate tex",
+						Description: "generate a LaTeX document",
 					},
-					DefaultValue: true,
+					DefaultValue: false,
 				},
 			},
 			// SubCommands: []*Command{
@@ -286,6 +409,63 @@ $ {{.AppName}} gen pdf
 			// 		},
 			// 	},
 			// },
+		}, {
+			BaseOpt: BaseOpt{
+				Short:       "c",
+				Full:        "code",
+				Aliases:     []string{"go"},
+				Description: "generate the go source codes of a command tree from a yaml/json spec file.",
+				LongDescription: `
+The generated codes build the command tree with the fluent api of cmdr,
+and the stub functions of the referenced actions will be written into
+separated files. The stubs are never overwritten on regeneration, so
+it's safe to be used with go:generate:
+
+	//go:generate go run github.com/hedzr/cmdr/examples/cmdr-gen gen code --spec cli.yml
+
+				`,
+				Action: genCode,
+			},
+			Flags: []*Flag{
+				{
+					BaseOpt: BaseOpt{
+						Short:       "s",
+						Full:        "spec",
+						Description: "the command spec file (.yml/.yaml/.json)",
+					},
+					DefaultValue:            "",
+					DefaultValuePlaceholder: "FILE",
+				},
+				{
+					BaseOpt: BaseOpt{
+						Short:       "d",
+						Full:        "dir",
+						Description: "the output directory",
+						Group:       "output",
+					},
+					DefaultValue:            ".",
+					DefaultValuePlaceholder: "DIR",
+				},
+				{
+					BaseOpt: BaseOpt{
+						Short:       "p",
+						Full:        "package",
+						Description: "the package name, default is $GOPACKAGE or 'main'",
+						Group:       "output",
+					},
+					DefaultValue:            "",
+					DefaultValuePlaceholder: "NAME",
+				},
+				{
+					BaseOpt: BaseOpt{
+						Full:        "func",
+						Description: "the name of the generated function",
+						Group:       "output",
+					},
+					DefaultValue:            "BuildRootCmd",
+					DefaultValuePlaceholder: "NAME",
+				},
+			},
 		}},
 	}
 )
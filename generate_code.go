@@ -0,0 +1,579 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"gopkg.in/hedzr/errors.v2"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// genCode generates the go source codes from a command spec file.
+//
+// It is go:generate friendly, for example:
+//
+//     //go:generate go run github.com/hedzr/cmdr/examples/cmdr-gen gen code --spec cli.yml
+//
+func genCode(command *Command, args []string) (err error) {
+	prefix := strings.Join(append(internalGetWorker().rxxtPrefixes, "generate.code"), ".")
+
+	file := GetStringP(prefix, "spec")
+	if len(file) == 0 && len(args) > 0 {
+		file = args[0]
+	}
+	if len(file) == 0 {
+		return errors.New("a command spec file is required, use '--spec FILE'")
+	}
+
+	var spec *RootSpec
+	if spec, err = LoadRootSpecFromFile(file); err != nil {
+		return
+	}
+
+	pkg := GetStringP(prefix, "package")
+	if len(pkg) == 0 {
+		// go generate exports the package name of the file containing the directive
+		pkg = os.Getenv("GOPACKAGE")
+	}
+
+	var files []string
+	files, err = GenerateGoSource(spec, GetStringP(prefix, "dir"), pkg, GetStringP(prefix, "func"))
+	for _, fn := range files {
+		log.Printf("'%v' generated...", fn)
+	}
+	return
+}
+
+// LoadRootSpecFromFile decodes a yaml/json command spec file without
+// building it.
+func LoadRootSpecFromFile(file string) (spec *RootSpec, err error) {
+	var f *os.File
+	if f, err = os.Open(file); err != nil {
+		return
+	}
+	defer f.Close()
+	if spec, err = LoadRootSpecFrom(f, path.Ext(file)); err != nil {
+		err = errors.New("error in loading command spec file '%s': %v", file, err)
+	}
+	return
+}
+
+// GenerateGoSource emits the go source codes which build the whole
+// command tree described by `spec` with the fluent api of cmdr.
+//
+// The tree file `cmdr_tree_gen.go` will be overwritten at each time.
+// For each action name referenced in the spec, a stub function will be
+// written into `action_<name>.go`, but only if that function hasn't
+// been defined in any go file under `dir`, so your implementations are
+// never overwritten on regeneration.
+//
+// `pkg` defaults to "main", `funcName` defaults to "BuildRootCmd".
+func GenerateGoSource(spec *RootSpec, dir, pkg, funcName string) (files []string, err error) {
+	if len(dir) == 0 {
+		dir = "."
+	}
+	if len(pkg) == 0 {
+		pkg = "main"
+	}
+	if len(funcName) == 0 {
+		funcName = "BuildRootCmd"
+	}
+	if err = EnsureDir(dir); err != nil {
+		return
+	}
+
+	g := &codeGenerator{
+		actions:  make(map[string]bool),
+		varNames: make(map[string]bool),
+	}
+
+	var src []byte
+	if src, err = g.genTree(spec, pkg, funcName); err != nil {
+		return
+	}
+	fn := path.Join(dir, "cmdr_tree_gen.go")
+	if err = ioutil.WriteFile(fn, src, 0644); err != nil {
+		return
+	}
+	files = append(files, fn)
+
+	var defined map[string]bool
+	if defined, err = definedGoFuncs(dir); err != nil {
+		return
+	}
+
+	var names []string
+	for name := range g.actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if defined[name] {
+			continue
+		}
+		if src, err = g.genActionStub(pkg, name, g.actions[name]); err != nil {
+			return
+		}
+		fn = path.Join(dir, fmt.Sprintf("action_%v.go", goSnakeName(strings.TrimSuffix(name, "Action"))))
+		if FileExists(fn) {
+			err = errors.New("cannot write the stub of '%v', file '%v' exists", name, fn)
+			return
+		}
+		if err = ioutil.WriteFile(fn, src, 0644); err != nil {
+			return
+		}
+		files = append(files, fn)
+	}
+	return
+}
+
+type codeGenerator struct {
+	buf bytes.Buffer
+	// actions holds the identifiers of the referenced actions, the
+	// value is true for a post-action.
+	actions  map[string]bool
+	varNames map[string]bool
+	usesTime bool
+}
+
+func (g *codeGenerator) p(fmtStr string, args ...interface{}) {
+	_, _ = fmt.Fprintf(&g.buf, fmtStr, args...)
+}
+
+func (g *codeGenerator) genTree(spec *RootSpec, pkg, funcName string) (src []byte, err error) {
+	g.p("root := cmdr.Root(%q, %q)", spec.AppName, spec.Version)
+	if len(spec.Copyright) > 0 || len(spec.Author) > 0 {
+		g.p(".\nCopyright(%s, %s)", goStrLit(spec.Copyright), goStrLit(spec.Author))
+	}
+	if len(spec.Header) > 0 {
+		g.p(".\nHeader(%s)", goStrLit(spec.Header))
+	}
+	g.p("\nrootCmd = root.RootCommand()\n")
+
+	var chain string
+	if chain, err = g.genCommandActions(&spec.CommandSpec); err != nil {
+		return
+	}
+	if chain = g.genBaseOpt(&spec.BaseOptSpec) + chain; len(chain) > 0 {
+		g.p("root%s\n", chain)
+	}
+	if err = g.genCommandBody("root", &spec.CommandSpec); err != nil {
+		return
+	}
+
+	if len(spec.PostActions) > 0 {
+		var fns []string
+		for _, name := range spec.PostActions {
+			var ident string
+			if ident, err = g.action(name, true); err != nil {
+				return
+			}
+			fns = append(fns, ident)
+		}
+		g.p("\nrootCmd.AppendPostActions(%v)\n", strings.Join(fns, ", "))
+	}
+
+	var locales []string
+	for locale := range spec.Translations {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	for _, locale := range locales {
+		table := spec.Translations[locale]
+		var keys []string
+		for k := range table {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		g.p("\ncmdr.AddTranslations(%q, map[string]string{\n", locale)
+		for _, k := range keys {
+			g.p("%v: %v,\n", goStrLit(k), goStrLit(table[k]))
+		}
+		g.p("})\n")
+	}
+
+	var out bytes.Buffer
+	_, _ = fmt.Fprintf(&out, "// Code generated by hedzr/cmdr (gen code); DO NOT EDIT.\n\npackage %v\n\n", pkg)
+	out.WriteString("import (\n\t\"github.com/hedzr/cmdr\"\n")
+	if g.usesTime {
+		out.WriteString("\t\"time\"\n")
+	}
+	out.WriteString(")\n\n")
+	_, _ = fmt.Fprintf(&out, "// %v builds the command tree of %v\n", funcName, spec.AppName)
+	_, _ = fmt.Fprintf(&out, "func %v() (rootCmd *cmdr.RootCommand) {\n", funcName)
+	out.Write(g.buf.Bytes())
+	out.WriteString("return\n}\n")
+
+	src, err = format.Source(out.Bytes())
+	return
+}
+
+func (g *codeGenerator) genCommandBody(varName string, s *CommandSpec) (err error) {
+	// the flags referenced by replaced-by need variables
+	flagVars := make(map[string]string)
+	for _, fs := range s.Flags {
+		if len(fs.ReplacedBy) > 0 {
+			flagVars[fs.Full], flagVars[fs.ReplacedBy] = "", ""
+		}
+	}
+	for _, fs := range s.Flags {
+		if _, ok := flagVars[fs.Full]; ok {
+			flagVars[fs.Full] = g.varName(varName, fs.Full+"-flag")
+		}
+		if err = g.genFlag(varName, flagVars[fs.Full], fs); err != nil {
+			return
+		}
+	}
+	for _, fs := range s.Flags {
+		if len(fs.ReplacedBy) > 0 {
+			if len(flagVars[fs.ReplacedBy]) == 0 {
+				return errors.New("flag '%v': replaced-by flag '%v' not found", fs.Full, fs.ReplacedBy)
+			}
+			g.p("%v.ReplacedBy(%v)\n", flagVars[fs.Full], flagVars[fs.ReplacedBy])
+		}
+	}
+
+	for _, cs := range s.SubCommands {
+		var name string
+		if len(cs.Flags) == 0 && len(cs.SubCommands) == 0 {
+			// a leaf command without flags needs no variable
+			g.p("\n%v.NewSubCommand(%v)", varName, goTitles(&cs.BaseOptSpec))
+		} else {
+			name = g.varName(varName, cs.Full, cs.Short, cs.Name)
+			g.p("\n%v := %v.NewSubCommand(%v)", name, varName, goTitles(&cs.BaseOptSpec))
+		}
+		var chain string
+		if chain, err = g.genCommandActions(cs); err != nil {
+			return
+		}
+		g.p("%v%v\n", g.genBaseOpt(&cs.BaseOptSpec), chain)
+		if err = g.genCommandBody(name, cs); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (g *codeGenerator) genCommandActions(s *CommandSpec) (chain string, err error) {
+	var sb strings.Builder
+	var ident string
+	if len(s.Action) > 0 {
+		if ident, err = g.action(s.Action, false); err != nil {
+			return
+		}
+		sb.WriteString(fmt.Sprintf(".\nAction(%v)", ident))
+	}
+	if len(s.PreAction) > 0 {
+		if ident, err = g.action(s.PreAction, false); err != nil {
+			return
+		}
+		sb.WriteString(fmt.Sprintf(".\nPreAction(%v)", ident))
+	}
+	if len(s.PostAction) > 0 {
+		if ident, err = g.action(s.PostAction, true); err != nil {
+			return
+		}
+		sb.WriteString(fmt.Sprintf(".\nPostAction(%v)", ident))
+	}
+	if len(s.TailPlaceHolder) > 0 {
+		sb.WriteString(fmt.Sprintf(".\nTailPlaceholder(%v)", goStrLit(s.TailPlaceHolder)))
+	}
+	chain = sb.String()
+	return
+}
+
+func (g *codeGenerator) genFlag(varName, flagVar string, s *FlagSpec) (err error) {
+	var dv interface{}
+	if dv, err = specDefaultValue(s.Type, s.Default); err != nil {
+		err = errors.New("flag '%v': %v", s.Full, err)
+		return
+	}
+
+	var lit string
+	if lit, err = g.goValueLit(dv); err != nil {
+		err = errors.New("flag '%v': %v", s.Full, err)
+		return
+	}
+
+	if len(flagVar) > 0 {
+		g.p("%v := ", flagVar)
+	}
+	g.p("%v.NewFlagV(%v, %v)", varName, lit, goTitles(&s.BaseOptSpec))
+	g.p("%v", g.genBaseOpt(&s.BaseOptSpec))
+	if len(s.Placeholder) > 0 {
+		g.p(".\nPlaceholder(%v)", goStrLit(s.Placeholder))
+	}
+	if s.Required {
+		g.p(".\nRequired(true)")
+	}
+	if len(s.ToggleGroup) > 0 {
+		g.p(".\nToggleGroup(%v)", goStrLit(s.ToggleGroup))
+	}
+	if len(s.ValidArgs) > 0 {
+		g.p(".\nValidArgs(%v)", goStrLits(s.ValidArgs))
+	}
+	if len(s.ExternalTool) > 0 {
+		g.p(".\nExternalTool(%v)", goStrLit(s.ExternalTool))
+	}
+	if len(s.EnvVars) > 0 {
+		g.p(".\nEnvKeys(%v)", goStrLits(s.EnvVars))
+	}
+	if s.HeadLike {
+		g.p(".\nHeadLike(true, %v, %v)", s.Min, s.Max)
+	}
+	if len(s.Action) > 0 {
+		var ident string
+		if ident, err = g.action(s.Action, false); err != nil {
+			return
+		}
+		g.p(".\nAction(%v)", ident)
+	}
+	g.p("\n")
+	return
+}
+
+// genBaseOpt returns the chained calls for the common fields.
+// The titles and the action are processed by the callers.
+func (g *codeGenerator) genBaseOpt(s *BaseOptSpec) string {
+	var sb strings.Builder
+	if len(s.Description) > 0 || len(s.LongDescription) > 0 {
+		if len(s.LongDescription) > 0 {
+			sb.WriteString(fmt.Sprintf(".\nDescription(%v, %v)", goStrLit(s.Description), goStrLit(s.LongDescription)))
+		} else {
+			sb.WriteString(fmt.Sprintf(".\nDescription(%v)", goStrLit(s.Description)))
+		}
+	}
+	if len(s.Examples) > 0 {
+		sb.WriteString(fmt.Sprintf(".\nExamples(%v)", goStrLit(s.Examples)))
+	}
+	if len(s.Group) > 0 {
+		sb.WriteString(fmt.Sprintf(".\nGroup(%v)", goStrLit(s.Group)))
+	}
+	if s.Hidden {
+		sb.WriteString(".\nHidden(true)")
+	}
+	if len(s.Deprecated) > 0 {
+		sb.WriteString(fmt.Sprintf(".\nDeprecated(%v)", goStrLit(s.Deprecated)))
+	}
+	if len(s.RemovedIn) > 0 {
+		sb.WriteString(fmt.Sprintf(".\nRemovedIn(%v)", goStrLit(s.RemovedIn)))
+	}
+	return sb.String()
+}
+
+// action returns the go identifier of a named action, and records it
+// for generating the stub later.
+func (g *codeGenerator) action(name string, post bool) (ident string, err error) {
+	ident = goIdent(name)
+	if post {
+		ident += "PostAction"
+	} else {
+		ident += "Action"
+	}
+	if p, ok := g.actions[ident]; ok && p != post {
+		err = errors.New("action '%v' is used as both an action and a post-action", name)
+		return
+	}
+	g.actions[ident] = post
+	return
+}
+
+func (g *codeGenerator) genActionStub(pkg, ident string, post bool) (src []byte, err error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("// Generated by hedzr/cmdr (gen code) as a stub, it's yours now.\n\npackage %v\n\n", pkg))
+	sb.WriteString("import \"github.com/hedzr/cmdr\"\n\n")
+	if post {
+		sb.WriteString(fmt.Sprintf("func %v(cmd *cmdr.Command, args []string) {\n\t// TODO implement it\n}\n", ident))
+	} else {
+		sb.WriteString(fmt.Sprintf("func %v(cmd *cmdr.Command, args []string) (err error) {\n\t// TODO implement it\n\treturn\n}\n", ident))
+	}
+	src, err = format.Source([]byte(sb.String()))
+	return
+}
+
+func (g *codeGenerator) varName(parent string, titles ...string) (name string) {
+	base := "cmd"
+	if parent != "root" {
+		base = parent
+	}
+	for _, t := range titles {
+		if len(t) > 0 {
+			base += goIdentExported(t)
+			break
+		}
+	}
+	name = base
+	for i := 2; g.varNames[name]; i++ {
+		name = fmt.Sprintf("%v%d", base, i)
+	}
+	g.varNames[name] = true
+	return
+}
+
+func (g *codeGenerator) goValueLit(v interface{}) (lit string, err error) {
+	switch vv := v.(type) {
+	case bool:
+		lit = strconv.FormatBool(vv)
+	case int:
+		lit = strconv.Itoa(vv)
+	case int64:
+		lit = fmt.Sprintf("int64(%d)", vv)
+	case uint:
+		lit = fmt.Sprintf("uint(%d)", vv)
+	case uint64:
+		lit = fmt.Sprintf("uint64(%d)", vv)
+	case float32:
+		lit = fmt.Sprintf("float32(%v)", strconv.FormatFloat(float64(vv), 'g', -1, 32))
+	case float64:
+		lit = fmt.Sprintf("float64(%v)", strconv.FormatFloat(vv, 'g', -1, 64))
+	case complex128:
+		lit = fmt.Sprintf("complex(%v, %v)", strconv.FormatFloat(real(vv), 'g', -1, 64), strconv.FormatFloat(imag(vv), 'g', -1, 64))
+	case string:
+		lit = goStrLit(vv)
+	case time.Duration:
+		g.usesTime = true
+		lit = goDurationLit(vv)
+	case []string:
+		lit = fmt.Sprintf("[]string{%v}", goStrLits(vv))
+	case []int:
+		lit = fmt.Sprintf("[]int{%v}", goNumLits(vv))
+	case []int64:
+		lit = fmt.Sprintf("[]int64{%v}", goNumLits(vv))
+	case []uint64:
+		lit = fmt.Sprintf("[]uint64{%v}", goNumLits(vv))
+	default:
+		err = errors.New("unsupported default value type %T", v)
+	}
+	return
+}
+
+// goNumLits joins the elements of a numeric slice with commas.
+func goNumLits(a interface{}) string {
+	return strings.Join(strings.Fields(strings.Trim(fmt.Sprint(a), "[]")), ", ")
+}
+
+func goDurationLit(d time.Duration) string {
+	for _, u := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	} {
+		if d != 0 && d%u.d == 0 {
+			return fmt.Sprintf("%d * %v", d/u.d, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
+
+func goTitles(s *BaseOptSpec) string {
+	long := s.Full
+	if len(long) == 0 {
+		long = s.Name
+	}
+	titles := []string{long}
+	if len(s.Short) > 0 || len(s.Aliases) > 0 {
+		titles = append(titles, s.Short)
+		titles = append(titles, s.Aliases...)
+	}
+	return goStrLits(titles)
+}
+
+func goStrLit(s string) string {
+	if strings.Contains(s, "\n") && !strings.ContainsAny(s, "`\r") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+func goStrLits(a []string) string {
+	var lits []string
+	for _, s := range a {
+		lits = append(lits, goStrLit(s))
+	}
+	return strings.Join(lits, ", ")
+}
+
+// goIdent converts a name such as 'spec-deploy' to a lower camel
+// case go identifier 'specDeploy'.
+func goIdent(name string) string {
+	s := goIdentExported(name)
+	if len(s) == 0 {
+		return "x"
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func goIdentExported(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if sb.Len() == 0 && unicode.IsDigit(r) {
+			sb.WriteRune('X')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func goSnakeName(ident string) string {
+	var sb strings.Builder
+	for i, r := range ident {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+var goFuncDeclRE = regexp.MustCompile(`(?m)^func\s+([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+
+// definedGoFuncs scans the go files under `dir` for the top-level
+// function declarations.
+func definedGoFuncs(dir string) (defined map[string]bool, err error) {
+	defined = make(map[string]bool)
+	var files []string
+	if files, err = filepath.Glob(path.Join(dir, "*.go")); err != nil {
+		return
+	}
+	for _, fn := range files {
+		var b []byte
+		if b, err = ioutil.ReadFile(fn); err != nil {
+			return
+		}
+		for _, m := range goFuncDeclRE.FindAllSubmatch(b, -1) {
+			defined[string(m[1])] = true
+		}
+	}
+	return
+}
@@ -5,43 +5,64 @@
 package cmdr
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"os"
 	"path"
 	"strings"
-	"text/template"
+	"time"
 )
 
 func genShell(cmd *Command, args []string) (err error) {
-	// logrus.Infof("OK gen shell. %v", *cmd)
 	w := internalGetWorker()
-	if GetBoolP(w.getPrefix(), "generate.shell.zsh") {
-		// if !GetBoolP(getPrefix(), "quiet") {
-		// 	logrus.Debugf("zsh-dump")
-		// }
-		// printHelpZsh(command, justFlags)
-
-		// not yet
-	} else if GetBoolP(w.getPrefix(), "generate.shell.bash") {
-		err = genShellBash(cmd, args)
-	} else {
-		// auto
-		// shell := os.Getenv("SHELL")
-		// if strings.HasSuffix(shell, "/bash") || GetBoolP(getPrefix(), "generate.shell.force-bash") {
-		// 	err = genShellBash(cmd, args)
-		// } else if strings.HasSuffix(shell, "/zsh") {
-		// 	// not yet
-		// }
-		err = genShellAuto(cmd, args)
-		// } else {
-		// 	_, _ = fmt.Fprint(os.Stderr, "Unknown shell. ignored.")
-		// err = genShellB(cmd, args)
+	prefix := w.getPrefix()
+	shell := detectShell()
+	for _, sh := range []string{"zsh", "fish", "powershell", "bash"} {
+		if GetBoolP(prefix, "generate.shell."+sh) {
+			shell = sh
+			break
+		}
+	}
+	if len(shell) == 0 {
+		if !GetBoolP(prefix, "generate.shell.force-bash") {
+			ferr("%v", T("Unknown shell, try --bash, --zsh, --fish or --powershell."))
+			return
+		}
+		shell = "bash"
+	}
+
+	script := completionScript(w.rootCommand, shell, GetBoolP(prefix, "generate.shell.dynamic"))
+	if GetBoolP(prefix, "generate.shell.install") || GetBoolP(prefix, "generate.shell.uninstall") {
+		err = w.installCompletion(shell, script, completionInstall{
+			dir:       GetStringP(prefix, "generate.shell.dir"),
+			dryRun:    GetBoolP(prefix, "generate.shell.dry-run"),
+			uninstall: GetBoolP(prefix, "generate.shell.uninstall"),
+		})
+		return
 	}
+	_, err = fmt.Fprint(w.rootCommand.ow, script)
 	return
 }
 
+// completionScript returns the completion script of root for the
+// shell, which is one of bash, zsh, fish and powershell.
+func completionScript(root *RootCommand, shell string, dynamic bool) string {
+	if dynamic && shell != "powershell" {
+		return dynamicCompletion(root, shell)
+	}
+	switch shell {
+	case "zsh":
+		return zshCompletion(root)
+	case "fish":
+		return fishCompletion(root)
+	case "powershell":
+		return powershellCompletion(root)
+	}
+	return bashCompletion(root)
+}
+
 // findDepth returns the depth of a command. rootCommand's deep = 1.
 func findDepth(cmd *Command) (deep int) {
 	deep = 1
@@ -51,114 +72,117 @@ func findDepth(cmd *Command) (deep int) {
 	return
 }
 
-// func findLvl(cmd *Command, lvl int) (lvlMax int) {
-// 	lvlMax = lvl + 1
-// 	for _, cc := range cmd.SubCommands {
-// 		l := findLvl(cc, lvl+1)
-// 		if l > lvlMax {
-// 			lvlMax = l
-// 		}
-// 	}
-// 	return
-// }
-
-func genShellAuto(cmd *Command, args []string) (err error) {
-	return
+// shellCommand is a visible command collected by shellCommands for
+// the completion generators.
+type shellCommand struct {
+	cmd *Command
+	// path is the full names from the root command, the app name is
+	// not included
+	path []string
+	// flags is the visible flags of cmd and its parents, since the
+	// flags of a parent command can be used in its subcommands too
+	flags []*Flag
+	// subs is the visible subcommands
+	subs []*Command
 }
 
-func genShellBash(cmd *Command, args []string) (err error) {
-	tmpl := template.New("bash.completion")
-	tmpl.Parse(`
-
-#
-
-# bash completion wrapper for {{.AppName}}
-# version: {{.Version}}
-#
-# Copyright (c) 2019-2025 Hedzr Yeh <hedzrz@gmail.com>
-#
+// shellCommands returns the visible commands under root in depth-first
+// order, root comes first.
+func shellCommands(root *Command) (list []*shellCommand) {
+	var collect func(cmd *Command, path []string, inherited []*Flag)
+	collect = func(cmd *Command, path []string, inherited []*Flag) {
+		sc := &shellCommand{cmd: cmd, path: path}
+		for _, flg := range cmd.Flags {
+			if !flg.Hidden {
+				sc.flags = append(sc.flags, flg)
+			}
+		}
+		sc.flags = append(sc.flags, inherited...)
+		for _, cc := range cmd.SubCommands {
+			if !cc.Hidden {
+				sc.subs = append(sc.subs, cc)
+			}
+		}
+		list = append(list, sc)
 
-_cmdr_cmd_help_events () {
-  $* --help|grep "^  [^ \[\$\#\!/\\@\"']"|awk -F'   ' '{print $1}'|awk -F',' '{for (i=1;i<=NF;i++) print $i}'
+		for _, cc := range sc.subs {
+			p := append(append([]string{}, path...), cc.GetTitleName())
+			collect(cc, p, sc.flags)
+		}
+	}
+	collect(root, nil, nil)
+	return
 }
 
+// shellFlagTakesValue reports whether the flag needs a value, the bool
+// flags don't.
+func shellFlagTakesValue(flg *Flag) bool {
+	return !isBool(flg.DefaultValue) && !isNil1(flg.DefaultValue)
+}
 
-_cmdr_cmd_{{.AppName}}() {
-  local cmd="{{.AppName}}" cur prev words
-  _get_comp_words_by_ref cur prev words
-  if [ "$prev" != "" ]; then
-    unset 'words[${#words[@]}-1]'
-  fi
-
-  COMPREPLY=()
-  #pre=${COMP_WORDS[COMP_CWORD-1]}
-  #cur=${COMP_WORDS[COMP_CWORD]}
-
-  case "$prev" in
-    --help|--version)
-      COMPREPLY=()
-      return 0
-      ;;
-    $cmd)
-      COMPREPLY=( $(compgen -W "$(_cmdr_cmd_help_events $cmd)" -- ${cur}) )
-      return 0
-      ;;
-    *)
-      COMPREPLY=( $(compgen -W "$(_cmdr_cmd_help_events ${words[@]})" -- ${cur}) )
-      return 0
-      ;;
-  esac
-
-  #opts="--help --version -q --quiet -v --verbose --system --dest="
-  #opts="--help upgrade version deploy undeploy log ls ps start stop restart"
-  opts="--help"
-  cmds=$($cmd --help|grep "^  [^ \[\$\#\!/\\@\"']"|awk -F'   ' '{print $1}'|awk -F',' '{for (i=1;i<=NF;i++) print $i}')
-
-  COMPREPLY=( $(compgen -W "${opts} ${cmds}" -- ${cur}) )
-
-} # && complete -F _cmdr_cmd_{{.AppName}} {{.AppName}}
-
-if type complete >/dev/null 2>&1; then
-	# bash
-	complete -F _cmdr_cmd_{{.AppName}} {{.AppName}}
-else if type compdef >/dev/null 2>&1; then
-	# zsh
-	_cmdr_cmd_{{.AppName}}_zsh() { compadd $(_cmdr_cmd_{{.AppName}}); }
-	compdef _cmdr_cmd_{{.AppName}}_zsh {{.AppName}}
-fi; fi
-`)
-
-	linuxRoot := os.Getuid() == 0
-
-	for _, s := range []string{"/etc/bash_completion.d", "/usr/local/etc/bash_completion.d", "/tmp"} {
-		if FileExists(s) {
-			file := path.Join(s, cmd.root.AppName)
-			var f *os.File
-			if f, err = os.Create(file); err != nil {
-				if !linuxRoot {
-					continue
-				}
-				return
-			}
+// shellFlagPathKind returns "dir" or "file" if the flag looks like a
+// path-like flag, by its placeholder or its name.
+func shellFlagPathKind(flg *Flag) string {
+	if !shellFlagTakesValue(flg) || len(flg.ValidArgs) > 0 {
+		return ""
+	}
+	switch strings.ToUpper(flg.DefaultValuePlaceholder) {
+	case "DIR", "DIRECTORY", "FOLDER":
+		return "dir"
+	case "FILE", "PATH", "FILENAME":
+		return "file"
+	}
+	name := strings.ToLower(flg.Full)
+	switch {
+	case strings.HasSuffix(name, "dir") || strings.HasSuffix(name, "directory"):
+		return "dir"
+	case strings.HasSuffix(name, "file") || strings.HasSuffix(name, "path"):
+		return "file"
+	}
+	return ""
+}
 
-			err = tmpl.Execute(f, cmd.root)
-			if err == nil {
-				fmt.Printf(`''%v generated.
-Re-login to enable the new bash completion script.
-`, file)
-			}
-			if !linuxRoot {
-				break // for non-root user, we break file-writing loop and dump scripts to console too.
-			}
-			return
+// shellFlagPlaceholder returns the value name of a flag for the
+// completion messages.
+func shellFlagPlaceholder(flg *Flag) string {
+	if len(flg.DefaultValuePlaceholder) > 0 {
+		return flg.DefaultValuePlaceholder
+	}
+	return "VALUE"
+}
 
-		}
+// shellDesc returns the description in one line for the completion
+// scripts.
+func shellDesc(opt *BaseOpt) string {
+	desc := strings.Join(strings.Fields(opt.GetDescription()), " ")
+	if len(desc) == 0 {
+		desc = opt.GetTitleName()
 	}
+	return desc
+}
 
-	err = tmpl.Execute(os.Stdout, cmd.root)
-	return
+// shellFuncName returns a shell function name for the command path.
+func shellFuncName(appName string, path []string) string {
+	name := strings.Join(append([]string{appName}, path...), "_")
+	return "_" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
 }
 
+// func findLvl(cmd *Command, lvl int) (lvlMax int) {
+// 	lvlMax = lvl + 1
+// 	for _, cc := range cmd.SubCommands {
+// 		l := findLvl(cc, lvl+1)
+// 		if l > lvlMax {
+// 			lvlMax = l
+// 		}
+// 	}
+// 	return
+// }
+
 // // not complete
 // func genShellB(cmd *Command, args []string) (err error) {
 // 	// var sb strings.Builder
@@ -300,35 +324,49 @@ func genManual(command *Command, args []string) (err error) {
 	prefix := strings.Join(append(w.rxxtPrefixes, "generate.manual"), ".")
 	// logrus.Debugf("OK gen manual: hit=%v", cmd.strHit)
 	// paintFromCommand(newManPainter(), &rootCommand.Command, false)
+	dir, gz := GetStringP(prefix, "dir"), GetBoolP(prefix, "gzip")
+	if GetBoolP(prefix, "single") {
+		if err = EnsureDir(dir); err != nil {
+			return
+		}
+		painter.paintSinglePage(w)
+		return writeManPage(fmt.Sprintf("%s/%v.1", dir, w.rootCommand.AppName), painter.Results(), gz)
+	}
+
 	err = WalkAllCommands(func(cmd *Command, index int) (err error) {
 		painter.Reset()
 
-		dir := GetStringP(prefix, "dir")
 		if err = EnsureDir(dir); err != nil {
 			return
 		}
 
-		fn := cmd.root.AppName
-		if !cmd.IsRoot() {
-			cmds := replaceAll(w.backtraceCmdNames(cmd), ".", "-")
-			// if cmds == "generate" {
-			// 	cmds += ""
-			// }
-			if len(cmds) > 0 {
-				fn += "-" + cmds
-			}
-		}
-		fn = fmt.Sprintf("%s/%v.1", dir, fn)
-
+		fn := fmt.Sprintf("%s/%v.1", dir, manPageName(cmd))
 		w.paintFromCommand(painter, cmd, false)
-		if err = ioutil.WriteFile(fn, painter.Results(), 0644); err == nil {
-			log.Printf("'%v' generated...", fn)
-		}
+		err = writeManPage(fn, painter.Results(), gz)
 		return
 	})
 	return
 }
 
+// writeManPage writes a man page, or its gzipped version 'fn.gz'.
+func writeManPage(fn string, data []byte, gz bool) (err error) {
+	if gz {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err = zw.Write(data); err == nil {
+			err = zw.Close()
+		}
+		if err != nil {
+			return
+		}
+		fn, data = fn+".gz", buf.Bytes()
+	}
+	if err = ioutil.WriteFile(fn, data, 0644); err == nil {
+		log.Printf("'%v' generated...", fn)
+	}
+	return
+}
+
 //
 //
 // /////////////////////////////////////////
@@ -338,6 +376,19 @@ func genManual(command *Command, args []string) (err error) {
 func genDoc(command *Command, args []string) (err error) {
 	prefix := strings.Join(append(internalGetWorker().rxxtPrefixes, "generate.doc"), ".")
 	// logrus.Infof("OK gen doc: hit=%v", cmd.strHit)
+	if command.strHit == "html" || GetBoolP(prefix, "html") {
+		return genDocHTML(GetStringP(prefix, "dir"), GetBoolP(prefix, "single-page"))
+	}
+	if command.strHit == "tex" || GetBoolP(prefix, "tex") {
+		return genDocTex(GetStringP(prefix, "dir"))
+	}
+	if command.strHit == "docx" || GetBoolP(prefix, "docx") {
+		return genDocDocx(GetStringP(prefix, "dir"))
+	}
+	if GetBoolP(prefix, "single-page") {
+		return genDocMarkdownSingle(GetStringP(prefix, "dir"))
+	}
+
 	var painter Painter
 	switch command.strHit {
 	case "mkd", "m", "markdown":
@@ -346,17 +397,11 @@ func genDoc(command *Command, args []string) (err error) {
 		painter = newMarkdownPainter()
 	// case "man", "manual", "manpage", "man-page":
 	// 	painter = newManPainter()
-	// case "docx":
-	// 	painter = newMarkdownPainter()
-	// case "tex":
-	// 	painter = newMarkdownPainter()
 	default: // , "doc", "d"
 		if GetBoolP(prefix, "markdown") {
 			painter = newMarkdownPainter()
 		} else if GetBoolP(prefix, "pdf") {
 			painter = newMarkdownPainter()
-			// } else if GetBoolP(prefix, "tex") {
-			// 	painter = newMarkdownPainter()
 		} else {
 			painter = newMarkdownPainter()
 		}
@@ -391,3 +436,124 @@ func genDoc(command *Command, args []string) (err error) {
 
 	return
 }
+
+// genDocHTML generates a static html site, one page per command, or
+// a single page for all commands.
+func genDocHTML(dir string, singlePage bool) (err error) {
+	if err = EnsureDir(dir); err != nil {
+		return
+	}
+
+	w := internalGetWorker()
+	painter := newHTMLPainter(singlePage)
+	var body bytes.Buffer
+	err = WalkAllCommands(func(cmd *Command, index int) (err error) {
+		painter.Reset()
+		w.paintFromCommand(painter, cmd, false)
+		if singlePage {
+			body.Write(painter.Results())
+			return
+		}
+
+		fn := path.Join(dir, htmlPageName(cmd))
+		if err = ioutil.WriteFile(fn, painter.document(cmd, painter.Results()), 0644); err == nil {
+			log.Printf("'%v' generated...", fn)
+		}
+		return
+	})
+
+	if err == nil && singlePage {
+		fn := path.Join(dir, htmlPageName(&w.rootCommand.Command))
+		if err = ioutil.WriteFile(fn, painter.document(nil, body.Bytes()), 0644); err == nil {
+			log.Printf("'%v' generated...", fn)
+		}
+	}
+	return
+}
+
+// genDocTex generates a single LaTeX document for the whole command
+// tree.
+func genDocTex(dir string) (err error) {
+	if err = EnsureDir(dir); err != nil {
+		return
+	}
+
+	w := internalGetWorker()
+	painter := newTexPainter()
+	var body bytes.Buffer
+	err = WalkAllCommands(func(cmd *Command, index int) (err error) {
+		painter.Reset()
+		w.paintFromCommand(painter, cmd, false)
+		body.Write(painter.Results())
+		return
+	})
+
+	if err == nil {
+		fn := path.Join(dir, w.rootCommand.AppName+".tex")
+		if err = ioutil.WriteFile(fn, painter.document(body.Bytes()), 0644); err == nil {
+			log.Printf("'%v' generated...", fn)
+		}
+	}
+	return
+}
+
+// genDocDocx generates a single Word document (.docx) for the whole
+// command tree.
+func genDocDocx(dir string) (err error) {
+	if err = EnsureDir(dir); err != nil {
+		return
+	}
+
+	w := internalGetWorker()
+	painter := newDocxPainter()
+	var body bytes.Buffer
+	err = WalkAllCommands(func(cmd *Command, index int) (err error) {
+		painter.Reset()
+		w.paintFromCommand(painter, cmd, false)
+		body.Write(painter.Results())
+		return
+	})
+
+	if err == nil {
+		var doc bytes.Buffer
+		if err = writeDocx(&doc, w.rootCommand.AppName, body.Bytes()); err != nil {
+			return
+		}
+		fn := path.Join(dir, w.rootCommand.AppName+".docx")
+		if err = ioutil.WriteFile(fn, doc.Bytes(), 0644); err == nil {
+			log.Printf("'%v' generated...", fn)
+		}
+	}
+	return
+}
+
+// genDocMarkdownSingle generates one markdown reference for the whole
+// command tree, with a table of contents.
+func genDocMarkdownSingle(dir string) (err error) {
+	if err = EnsureDir(dir); err != nil {
+		return
+	}
+
+	w := internalGetWorker()
+	painter := newMarkdownPainter()
+	painter.singlePage = true
+
+	var doc bytes.Buffer
+	root := &w.rootCommand.Command
+	_, _ = fmt.Fprintf(&doc, "# %v v%v\n\n## Table of Contents\n\n%v", w.rootCommand.AppName, w.rootCommand.Version, markdownTOC(root))
+	err = walkVisibleCommands(root, 0, func(cmd *Command, index int) (err error) {
+		painter.Reset()
+		w.paintFromCommand(painter, cmd, false)
+		doc.Write(painter.Results())
+		return
+	})
+
+	if err == nil {
+		_, _ = fmt.Fprintf(&doc, "\n---\n\n%v Auto generated by [hedzr/cmdr](https://github.com/hedzr/cmdr)\n", time.Now().Format("02-Jan-2006"))
+		fn := path.Join(dir, w.rootCommand.AppName+".md")
+		if err = ioutil.WriteFile(fn, doc.Bytes(), 0644); err == nil {
+			log.Printf("'%v' generated...", fn)
+		}
+	}
+	return
+}
@@ -0,0 +1,176 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bashCompletion generates the bash completion script. The words before
+// the current one are walked through a case table to find the command
+// path, the values of the flags are skipped. And then the subcommands,
+// the flags or the values of the previous flag of that command path are
+// completed by the case tables.
+func bashCompletion(root *RootCommand) string {
+	var sb strings.Builder
+	app := root.AppName
+	fn := shellFuncName(app, nil)
+	list := shellCommands(&root.Command)
+
+	sb.WriteString(fmt.Sprintf(`# bash completion for %v %v
+#
+# Save this script into the user completions directory, for example:
+#
+#   mkdir -p ~/.local/share/bash-completion/completions
+#   %v generate shell --bash > ~/.local/share/bash-completion/completions/%v
+#
+# Or source it in ~/.bashrc.
+
+%v_completion() {
+  local cur="${COMP_WORDS[COMP_CWORD]}" prev="" flag="" path="" word words="" i skip=0
+  if ((COMP_CWORD > 1)); then
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+  fi
+
+  # '--flag=value' is split into '--flag', '=' and 'value' by bash
+  if [[ "$cur" == "=" ]]; then
+    flag="$prev" cur=""
+  elif [[ "$prev" == "=" ]] && ((COMP_CWORD > 2)); then
+    flag="${COMP_WORDS[COMP_CWORD-2]}"
+  elif [[ "$prev" == -* ]]; then
+    flag="$prev"
+  fi
+
+  for ((i = 1; i < COMP_CWORD; i++)); do
+    word="${COMP_WORDS[i]}"
+    if ((skip)); then
+      skip=0
+      continue
+    fi
+    if [[ "${COMP_WORDS[i+1]}" == "=" ]]; then
+      # the value is attached by '='
+      ((i += 2))
+      continue
+    fi
+    case "$path:$word" in
+`, app, root.Version, app, app, fn))
+
+	// the command paths
+	for _, sc := range list {
+		for _, cc := range sc.subs {
+			p := strings.Join(append(append([]string{}, sc.path...), cc.GetTitleName()), " ")
+			var patterns []string
+			for _, n := range cc.GetTitleNamesArray() {
+				patterns = append(patterns, bashQuote(strings.Join(sc.path, " ")+":"+n))
+			}
+			sb.WriteString(fmt.Sprintf("    %v) path=%v ;;\n", strings.Join(patterns, "|"), bashQuote(p)))
+		}
+	}
+	// the flags with a value
+	for _, sc := range list {
+		for _, flg := range sc.cmd.Flags {
+			if !flg.Hidden && shellFlagTakesValue(flg) {
+				sb.WriteString(fmt.Sprintf("    %v) skip=1 ;;\n", strings.Join(bashFlagPatterns(sc.path, flg), "|")))
+			}
+		}
+	}
+	sb.WriteString(`    esac
+  done
+
+  if [[ -n "$flag" ]]; then
+    case "$path:$flag" in
+`)
+
+	// the values of the flags
+	for _, sc := range list {
+		for _, flg := range sc.cmd.Flags {
+			if flg.Hidden || !shellFlagTakesValue(flg) {
+				continue
+			}
+			var action string
+			if len(flg.ValidArgs) > 0 {
+				action = fmt.Sprintf(`COMPREPLY=($(compgen -W %v -- "$cur"))`, bashQuote(strings.Join(flg.ValidArgs, " ")))
+			} else {
+				switch shellFlagPathKind(flg) {
+				case "dir":
+					action = `COMPREPLY=($(compgen -d -- "$cur"))`
+				case "file":
+					action = `COMPREPLY=($(compgen -f -- "$cur"))`
+				default:
+					action = "COMPREPLY=()"
+				}
+			}
+			sb.WriteString(fmt.Sprintf("    %v)\n      %v\n      return 0\n      ;;\n",
+				strings.Join(bashFlagPatterns(sc.path, flg), "|"), action))
+		}
+	}
+
+	sb.WriteString(`    esac
+  fi
+
+  if [[ "$cur" == -* ]]; then
+    case "$path" in
+`)
+	for _, sc := range list {
+		var names []string
+		for _, flg := range sc.flags {
+			for _, n := range flg.GetShortTitleNamesArray() {
+				names = append(names, "-"+n)
+			}
+			for _, n := range flg.GetLongTitleNamesArray() {
+				names = append(names, "--"+n)
+			}
+		}
+		if len(names) > 0 {
+			sb.WriteString(fmt.Sprintf("    %v) words=%v ;;\n", bashQuote(strings.Join(sc.path, " ")), bashQuote(strings.Join(names, " "))))
+		}
+	}
+	sb.WriteString(`    esac
+  else
+    case "$path" in
+`)
+	for _, sc := range list {
+		var names []string
+		for _, cc := range sc.subs {
+			names = append(names, cc.GetTitleNamesArray()...)
+		}
+		if len(names) > 0 {
+			sb.WriteString(fmt.Sprintf("    %v) words=%v ;;\n", bashQuote(strings.Join(sc.path, " ")), bashQuote(strings.Join(names, " "))))
+		}
+	}
+	sb.WriteString(fmt.Sprintf(`    esac
+  fi
+  COMPREPLY=($(compgen -W "$words" -- "$cur"))
+}
+
+complete -o default -F %v_completion %v
+`, fn, app))
+	return sb.String()
+}
+
+// bashFlagPatterns returns the case patterns `path:name` of a flag of
+// the command at path, which match the subcommands of path too.
+func bashFlagPatterns(path []string, flg *Flag) (patterns []string) {
+	p := strings.Join(path, " ")
+	var names []string
+	for _, n := range flg.GetShortTitleNamesArray() {
+		names = append(names, "-"+n)
+	}
+	for _, n := range flg.GetLongTitleNamesArray() {
+		names = append(names, "--"+n)
+	}
+	for _, n := range names {
+		if len(p) == 0 {
+			patterns = append(patterns, "*:"+bashQuote(n))
+		} else {
+			patterns = append(patterns, bashQuote(p+":"+n), bashQuote(p+" ")+"*:"+bashQuote(n))
+		}
+	}
+	return
+}
+
+// bashQuote quotes s as a single-quoted bash word.
+func bashQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
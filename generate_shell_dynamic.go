@@ -0,0 +1,144 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"strings"
+	"text/template"
+)
+
+// dynamicCompletion returns a thin completion script which asks the
+// app itself for the candidates by the hidden command `__complete`, so
+// the dynamic values from Flag.Completion and Command.Completion can be
+// completed.
+func dynamicCompletion(root *RootCommand, shell string) string {
+	var sb strings.Builder
+	tmpl := template.Must(template.New(shell).Parse(dynamicShims[shell]))
+	_ = tmpl.Execute(&sb, map[string]interface{}{
+		"AppName": root.AppName,
+		"Version": root.Version,
+		"Func":    shellFuncName(root.AppName, nil),
+		"Command": completeCommandName,
+	})
+	return sb.String()
+}
+
+// dynamicShims are the shell scripts calling `app __complete <words>`,
+// which prints a candidate in each line and a directive in the last
+// line, see completeWords.
+var dynamicShims = map[string]string{
+	"bash": `# bash completion for {{.AppName}} {{.Version}}
+#
+# It calls '{{.AppName}} {{.Command}}' for the candidates. Save it into
+# ~/.local/share/bash-completion/completions/{{.AppName}}, or source it
+# in ~/.bashrc.
+
+{{.Func}}_complete() {
+  local line="${COMP_LINE:0:COMP_POINT}" cur out directive
+  local -a words
+  read -r -a words <<< "$line"
+  if [[ "$line" == *[[:space:]] ]]; then
+    words+=("")
+  fi
+  cur="${words[${#words[@]}-1]}"
+
+  out="$("${words[0]}" {{.Command}} "${words[@]:1}" 2>/dev/null)" || return 0
+  directive="${out##*:}"
+  out="$(printf '%s\n' "$out" | sed '$d' | cut -f1)"
+
+  local IFS=$'\n'
+  case "$directive" in
+  files) COMPREPLY=($(compgen -f -- "${cur#*=}")) ;;
+  dirs) COMPREPLY=($(compgen -d -- "${cur#*=}")) ;;
+  *)
+    COMPREPLY=($out)
+    if [[ ${#COMPREPLY[@]} -eq 0 && "$directive" == default ]]; then
+      COMPREPLY=($(compgen -f -- "${cur#*=}"))
+    fi
+    ;;
+  esac
+
+  # bash splits '--flag=value' at '=', only the value is replaced
+  if [[ "$cur" == *=* && "$COMP_WORDBREAKS" == *=* ]]; then
+    COMPREPLY=("${COMPREPLY[@]#*=}")
+  fi
+}
+
+complete -o default -F {{.Func}}_complete {{.AppName}}
+`,
+
+	"zsh": `#compdef {{.AppName}}
+
+# zsh completion for {{.AppName}} {{.Version}}
+#
+# It calls '{{.AppName}} {{.Command}}' for the candidates. Save it as
+# '{{.Func}}' into a directory in $fpath, or source it in ~/.zshrc.
+
+{{.Func}}() {
+  local -a lines candidates
+  local directive line name
+
+  lines=("${(@f)$(${words[1]} {{.Command}} "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+  directive="${lines[-1]#:}"
+  for line in "${(@)lines[1,-2]}"; do
+    name="${line%%$'\t'*}"
+    if [[ "$line" == *$'\t'* ]]; then
+      candidates+=("${name//:/\\:}:${line#*$'\t'}")
+    else
+      candidates+=("${name//:/\\:}")
+    fi
+  done
+
+  case "$directive" in
+  files) _files ;;
+  dirs) _files -/ ;;
+  *)
+    if (( ${#candidates} )); then
+      _describe -t values 'candidates' candidates
+    elif [[ "$directive" == default ]]; then
+      _files
+    fi
+    ;;
+  esac
+}
+
+if [ "$funcstack[1]" = "{{.Func}}" ]; then
+  {{.Func}} "$@"
+else
+  compdef {{.Func}} {{.AppName}}
+fi
+`,
+
+	"fish": `# fish completion for {{.AppName}} {{.Version}}
+#
+# It calls '{{.AppName}} {{.Command}}' for the candidates. Save it as
+# ~/.config/fish/completions/{{.AppName}}.fish, or source it.
+
+function {{.Func}}_complete
+    set -l args (commandline -opc)
+    set -l cur (commandline -ct)
+    set -l cmd $args[1]
+    set -e args[1]
+
+    set -l out (command $cmd {{.Command}} $args "$cur" 2>/dev/null)
+    set -l directive (string replace -r '^:' '' -- $out[-1])
+    set -e out[-1]
+
+    switch "$directive"
+        case files
+            __fish_complete_path "$cur"
+        case dirs
+            __fish_complete_directories "$cur"
+        case '*'
+            if test (count $out) -gt 0
+                printf '%s\n' $out
+            else if test "$directive" = default
+                __fish_complete_path "$cur"
+            end
+    end
+end
+
+complete -c {{.AppName}} -e
+complete -c {{.AppName}} -f -a '({{.Func}}_complete)'
+`,
+}
@@ -0,0 +1,108 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fishCompletion generates the `complete -c app ...` lines. A command
+// is completed once its parents are seen on the command line, and its
+// flags are available in its subcommands too.
+func fishCompletion(root *RootCommand) string {
+	var sb strings.Builder
+	app := root.AppName
+
+	sb.WriteString(fmt.Sprintf(`# fish completion for %v %v
+#
+# Save this script into the fish completions directory, for example:
+#
+#   %v generate shell --fish > ~/.config/fish/completions/%v.fish
+#
+# Or just source it in the current shell.
+
+complete -c %v -e
+`, app, root.Version, app, app, app))
+
+	for _, sc := range shellCommands(&root.Command) {
+		var seen []string
+		cmd := sc.cmd
+		for c := cmd; c != nil && !c.IsRoot(); c = c.owner {
+			seen = append([]string{"__fish_seen_subcommand_from " + strings.Join(c.GetTitleNamesArray(), " ")}, seen...)
+		}
+
+		var lines []string
+		if len(sc.subs) > 0 {
+			var names []string
+			for _, cc := range sc.subs {
+				names = append(names, cc.GetTitleNamesArray()...)
+			}
+			cond := append(append([]string{}, seen...), "not __fish_seen_subcommand_from "+strings.Join(names, " "))
+			if cmd.IsRoot() {
+				cond = []string{"__fish_use_subcommand"}
+			}
+			for _, cc := range sc.subs {
+				for _, n := range cc.GetTitleNamesArray() {
+					lines = append(lines, fmt.Sprintf("complete -c %v -f -n %v -a %v -d %v",
+						app, fishQuote(strings.Join(cond, "; and ")), fishQuote(n), fishQuote(shellDesc(&cc.BaseOpt))))
+				}
+			}
+		}
+
+		for _, flg := range cmd.Flags {
+			if flg.Hidden {
+				continue
+			}
+			line := "complete -c " + app
+			if len(seen) > 0 {
+				line += " -n " + fishQuote(strings.Join(seen, "; and "))
+			}
+			for _, n := range flg.GetShortTitleNamesArray() {
+				if len(n) == 1 {
+					line += " -s " + n
+				} else {
+					line += " -o " + n
+				}
+			}
+			for _, n := range flg.GetLongTitleNamesArray() {
+				line += " -l " + n
+			}
+			line += fishFlagValue(flg)
+			lines = append(lines, fmt.Sprintf("%v -d %v", line, fishQuote(shellDesc(&flg.BaseOpt))))
+		}
+
+		if len(lines) > 0 {
+			sb.WriteString(fmt.Sprintf("\n# %v\n", strings.Join(append([]string{app}, sc.path...), " ")))
+			sb.WriteString(strings.Join(lines, "\n") + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// fishFlagValue returns the options of `complete` for the value of a
+// flag: the enumerations, the directories, the files or any string.
+func fishFlagValue(flg *Flag) string {
+	if !shellFlagTakesValue(flg) {
+		return ""
+	}
+	if len(flg.ValidArgs) > 0 {
+		var values []string
+		for _, v := range flg.ValidArgs {
+			values = append(values, zshEscape(v, " ()'\"$;&|*?"))
+		}
+		return " -x -a " + fishQuote(strings.Join(values, " "))
+	}
+	switch shellFlagPathKind(flg) {
+	case "dir":
+		return " -x -a '(__fish_complete_directories)'"
+	case "file":
+		return " -r -F"
+	}
+	return " -x"
+}
+
+// fishQuote quotes s as a single-quoted fish word.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
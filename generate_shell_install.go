@@ -0,0 +1,137 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// completionInstall holds the options of `generate shell --install`.
+type completionInstall struct {
+	// dir is the target directory instead of the user-level one, for
+	// packaging
+	dir       string
+	dryRun    bool
+	uninstall bool
+}
+
+// detectShell returns the name of the current shell by the parent
+// process, or by the env var SHELL. It returns empty string if the
+// shell is not one of bash, zsh, fish and powershell.
+func detectShell() (shell string) {
+	if b, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/comm", os.Getppid())); err == nil {
+		shell = shellName(string(b))
+	}
+	if len(shell) == 0 {
+		shell = shellName(os.Getenv("SHELL"))
+	}
+	return
+}
+
+// shellName normalizes a shell path or a process name, such as
+// "/bin/zsh", "-bash" or "pwsh".
+func shellName(s string) string {
+	s = strings.TrimPrefix(filepath.Base(strings.TrimSpace(s)), "-")
+	switch strings.TrimSuffix(strings.ToLower(s), ".exe") {
+	case "bash":
+		return "bash"
+	case "zsh":
+		return "zsh"
+	case "fish":
+		return "fish"
+	case "pwsh", "powershell":
+		return "powershell"
+	}
+	return ""
+}
+
+// completionTarget returns the directory and the file name of the
+// completion script for the shell, the directory is the user-level
+// one if dir is empty.
+func completionTarget(appName, shell, dir string) (targetDir, file string) {
+	home := os.Getenv("HOME")
+	xdg := func(key, fallback string) string {
+		if v := os.Getenv(key); len(v) > 0 {
+			return v
+		}
+		return path.Join(home, fallback)
+	}
+
+	switch shell {
+	case "zsh":
+		targetDir, file = path.Join(home, ".zsh", "completions"), shellFuncName(appName, nil)
+	case "fish":
+		targetDir, file = path.Join(xdg("XDG_CONFIG_HOME", ".config"), "fish", "completions"), appName+".fish"
+	case "powershell":
+		targetDir, file = path.Join(xdg("XDG_CONFIG_HOME", ".config"), "powershell", "completions"), appName+".ps1"
+	default:
+		targetDir, file = path.Join(xdg("XDG_DATA_HOME", path.Join(".local", "share")), "bash-completion", "completions"), appName
+		if v := os.Getenv("BASH_COMPLETION_USER_DIR"); len(v) > 0 {
+			targetDir = path.Join(v, "completions")
+		}
+	}
+	if len(dir) > 0 {
+		targetDir = dir
+	}
+	return
+}
+
+// installCompletion writes the script into the completions directory
+// of the shell, or removes it, and prints a summary.
+func (w *ExecWorker) installCompletion(shell, script string, o completionInstall) (err error) {
+	dir, file := completionTarget(w.rootCommand.AppName, shell, o.dir)
+	file = path.Join(dir, file)
+	old, readErr := ioutil.ReadFile(file)
+	exists := readErr == nil
+
+	if o.uninstall {
+		switch {
+		case !exists:
+			fp("%v", T("The %v completion is not installed: %v", shell, file))
+		case o.dryRun:
+			fp("%v", T("Would remove the %v completion: %v", shell, file))
+		default:
+			if err = os.Remove(file); err == nil {
+				fp("%v", T("Removed the %v completion: %v", shell, file))
+			}
+		}
+		return
+	}
+
+	switch {
+	case exists && bytes.Equal(old, []byte(script)):
+		fp("%v", T("The %v completion is up to date: %v", shell, file))
+	case o.dryRun && exists:
+		fp("%v", T("Would update the %v completion: %v", shell, file))
+	case o.dryRun:
+		fp("%v", T("Would install the %v completion: %v", shell, file))
+	default:
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return
+		}
+		if err = ioutil.WriteFile(file, []byte(script), 0644); err != nil {
+			return
+		}
+		if exists {
+			fp("%v", T("Updated the %v completion: %v", shell, file))
+		} else {
+			fp("%v", T("Installed the %v completion: %v", shell, file))
+		}
+	}
+
+	switch shell {
+	case "zsh":
+		fp("%v", T("Add %q to ~/.zshrc before compinit if the directory is not in $fpath.", "fpath=("+dir+" $fpath)"))
+	case "powershell":
+		fp("%v", T("Add %q to your $PROFILE to load it.", ". "+file))
+	default:
+		fp("%v", T("Start a new shell to take effect."))
+	}
+	return
+}
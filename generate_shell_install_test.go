@@ -0,0 +1,86 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestInstallCompletion(t *testing.T) {
+	home, err := ioutil.TempDir("", "cmdr-install")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	for _, key := range []string{"HOME", "XDG_CONFIG_HOME", "XDG_DATA_HOME", "BASH_COMPLETION_USER_DIR"} {
+		if v, ok := os.LookupEnv(key); ok {
+			defer os.Setenv(key, v)
+		} else {
+			defer os.Unsetenv(key)
+		}
+		_ = os.Unsetenv(key)
+	}
+	_ = os.Setenv("HOME", home)
+
+	w := internalGetWorker()
+	saved := w.rootCommand
+	defer func() { w.rootCommand = saved }()
+
+	var buf bytes.Buffer
+	w.rootCommand = &RootCommand{AppName: "comp-app"}
+	w.rootCommand.ow = bufio.NewWriter(&buf)
+
+	fish := home + "/.config/fish/completions/comp-app.fish"
+	zshDir := home + "/zfunc"
+	for _, c := range []struct {
+		shell, script string
+		o             completionInstall
+		expect        string
+	}{
+		{"fish", "complete -c comp-app -e\n", completionInstall{dryRun: true}, "Would install the fish completion: " + fish},
+		{"fish", "complete -c comp-app -e\n", completionInstall{}, "Installed the fish completion: " + fish},
+		{"fish", "complete -c comp-app -e\n", completionInstall{}, "The fish completion is up to date: " + fish},
+		{"fish", "complete -c comp-app\n", completionInstall{}, "Updated the fish completion: " + fish},
+		{"fish", "", completionInstall{uninstall: true, dryRun: true}, "Would remove the fish completion: " + fish},
+		{"fish", "", completionInstall{uninstall: true}, "Removed the fish completion: " + fish},
+		{"fish", "", completionInstall{uninstall: true}, "The fish completion is not installed: " + fish},
+		{"zsh", "#compdef comp-app\n", completionInstall{dir: zshDir}, "fpath=(" + zshDir + " $fpath)"},
+	} {
+		buf.Reset()
+		if err = w.installCompletion(c.shell, c.script, c.o); err != nil {
+			t.Fatal(err)
+		}
+		_ = w.rootCommand.ow.Flush()
+		if !strings.Contains(buf.String(), c.expect) {
+			t.Fatalf("%v %+v: expect %q, but got:\n%v", c.shell, c.o, c.expect, buf.String())
+		}
+	}
+
+	if _, err = os.Stat(fish); !os.IsNotExist(err) {
+		t.Fatalf("expect %v removed, but got: %v", fish, err)
+	}
+	if b, err := ioutil.ReadFile(zshDir + "/_comp_app"); err != nil || string(b) != "#compdef comp-app\n" {
+		t.Fatalf("bad zsh completion installed: %q, %v", b, err)
+	}
+}
+
+func TestShellName(t *testing.T) {
+	for in, expect := range map[string]string{
+		"/bin/zsh":       "zsh",
+		"-bash":          "bash",
+		"fish\n":         "fish",
+		"pwsh":           "powershell",
+		"powershell.exe": "powershell",
+		"/usr/bin/tcsh":  "",
+	} {
+		if got := shellName(in); got != expect {
+			t.Fatalf("shellName(%q): expect %q, but got %q", in, expect, got)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// powershellCompletion generates a `Register-ArgumentCompleter` script.
+// The subcommands, the flags and the aliases of every command path are
+// kept in the tables $commands, $flags and $resolve, the script block
+// walks the typed words through $resolve to find the current command.
+func powershellCompletion(root *RootCommand) string {
+	var sb strings.Builder
+	app := root.AppName
+	list := shellCommands(&root.Command)
+
+	sb.WriteString(fmt.Sprintf(`# powershell completion for %v %v
+#
+# Load it in the current session, or add this line to your $PROFILE:
+#
+#   %v generate shell --powershell | Out-String | Invoke-Expression
+
+Register-ArgumentCompleter -Native -CommandName %v -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $commands = @{
+`, app, root.Version, app, psQuote(app)))
+
+	for _, sc := range list {
+		sb.WriteString(fmt.Sprintf("        %v = @(\n", psQuote(strings.Join(sc.path, " "))))
+		for _, cc := range sc.subs {
+			for _, n := range cc.GetTitleNamesArray() {
+				sb.WriteString(fmt.Sprintf("            @{ Name = %v; Tip = %v }\n", psQuote(n), psQuote(shellDesc(&cc.BaseOpt))))
+			}
+		}
+		sb.WriteString("        )\n")
+	}
+
+	sb.WriteString("    }\n    $flags = @{\n")
+	for _, sc := range list {
+		sb.WriteString(fmt.Sprintf("        %v = @(\n", psQuote(strings.Join(sc.path, " "))))
+		for _, flg := range sc.flags {
+			var names []string
+			for _, n := range flg.GetShortTitleNamesArray() {
+				names = append(names, "-"+n)
+			}
+			for _, n := range flg.GetLongTitleNamesArray() {
+				names = append(names, "--"+n)
+			}
+
+			value := "$false"
+			if shellFlagTakesValue(flg) {
+				value = "$true"
+			}
+			var values []string
+			for _, v := range flg.ValidArgs {
+				if len(v) > 0 {
+					values = append(values, psQuote(v))
+				}
+			}
+			for _, n := range names {
+				sb.WriteString(fmt.Sprintf("            @{ Name = %v; Tip = %v; Value = %v; Values = @(%v) }\n",
+					psQuote(n), psQuote(shellDesc(&flg.BaseOpt)), value, strings.Join(values, ", ")))
+			}
+		}
+		sb.WriteString("        )\n")
+	}
+
+	sb.WriteString("    }\n    $resolve = @{\n")
+	for _, sc := range list {
+		for _, cc := range sc.subs {
+			p := strings.Join(append(append([]string{}, sc.path...), cc.GetTitleName()), " ")
+			for _, n := range cc.GetTitleNamesArray() {
+				sb.WriteString(fmt.Sprintf("        %v = %v\n", psQuote(strings.Join(sc.path, " ")+"|"+n), psQuote(p)))
+			}
+		}
+	}
+
+	sb.WriteString(`    }
+
+    $path = ''
+    $flag = $null
+    $words = @($commandAst.CommandElements | Select-Object -Skip 1 |
+        Where-Object { $_.Extent.EndOffset -lt $cursorPosition } | ForEach-Object { "$_" })
+    foreach ($w in $words) {
+        if ($flag) {
+            # the value of the previous flag
+            $flag = $null
+        } elseif ($w.StartsWith('-')) {
+            if (-not $w.Contains('=')) {
+                $flag = $flags[$path] | Where-Object { $_.Name -eq $w -and $_.Value } | Select-Object -First 1
+            }
+        } elseif ($resolve.ContainsKey("$path|$w")) {
+            $path = $resolve["$path|$w"]
+        }
+    }
+
+    $prefix = ''
+    if (-not $flag -and $wordToComplete -match '^(-[^=]+)=(.*)$') {
+        $flag = $flags[$path] | Where-Object { $_.Name -eq $Matches[1] -and $_.Value } | Select-Object -First 1
+        $prefix, $wordToComplete = "$($Matches[1])=", $Matches[2]
+    }
+
+    if ($flag) {
+        $flag.Values | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+            [System.Management.Automation.CompletionResult]::new("$prefix$_", $_, 'ParameterValue', $_)
+        }
+    } elseif ($wordToComplete.StartsWith('-')) {
+        $flags[$path] | Where-Object { $_.Name -like "$wordToComplete*" } | ForEach-Object {
+            [System.Management.Automation.CompletionResult]::new($_.Name, $_.Name, 'ParameterName', $_.Tip)
+        }
+    } else {
+        $commands[$path] | Where-Object { $_.Name -like "$wordToComplete*" } | ForEach-Object {
+            [System.Management.Automation.CompletionResult]::new($_.Name, $_.Name, 'ParameterValue', $_.Tip)
+        }
+    }
+}
+`)
+	return sb.String()
+}
+
+// psQuote quotes s as a single-quoted PowerShell string.
+func psQuote(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
@@ -0,0 +1,186 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+// buildShellRoot returns a new tree for the completion generators, the
+// bool flags keep the parsed values so each case needs a new one.
+func buildShellRoot() *cmdr.RootCommand {
+	root := cmdr.Root("comp-app", "1.0.0")
+	server := root.NewSubCommand("server", "s").Description("server operations [ops]", "")
+	server.NewSubCommand("start", "st").Description("start the server", "")
+	server.NewSubCommand("stop").Description("stop the server", "")
+	server.NewFlagV(8080, "port", "p").Description("the listening port", "")
+	server.NewFlagV("info", "level").Description("the log level", "").ValidArgs("debug", "info", "warn")
+	server.NewFlagV("", "pid-file").Description("the pid file", "")
+	server.NewFlagV("", "work-dir", "w").Description("the working directory", "")
+	root.NewFlagV(false, "json").ToggleGroup("fmt").Description("json output", "")
+	root.NewFlagV(false, "yaml").ToggleGroup("fmt").Description("yaml output", "")
+	root.NewSubCommand("secret").Hidden(true)
+	return root.RootCommand()
+}
+
+// execShellGen runs `comp-app` with args and returns its output.
+func execShellGen(t *testing.T, args ...string) string {
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+	outX, _ := prepareStreams()
+
+	os.Args = append([]string{"comp-app"}, args...)
+	if err := cmdr.Exec(buildShellRoot(), cmdr.WithNoLoadConfigFiles(true)); err != nil {
+		t.Fatal(err)
+	}
+	cmdr.InternalResetWorker() // flush the outputs
+	cmdr.ResetOptions()
+	return outX.String()
+}
+
+func TestGenShellZsh(t *testing.T) {
+	defer resetOsArgs()
+
+	out := execShellGen(t, "gen", "shell", "--zsh")
+	for _, s := range []string{
+		"#compdef comp-app\n",
+		"fpath=(~/.zsh/completions $fpath)",
+		"_comp_app() {\n",
+		"        's:server operations [ops]'\n        'server:server operations [ops]'\n",
+		"        (s|server)\n          _comp_app_server && ret=0\n",
+		"_comp_app_server_start() {\n  _arguments \\\n",
+		`'(-p --port)-p+[the listening port]:VALUE:'`,
+		`'(-p --port)--port=[the listening port]:VALUE:'`,
+		`'(--level)--level=[the log level]:VALUE:(debug info warn)'`,
+		`'(--pid-file)--pid-file=[the pid file]:VALUE:_files'`,
+		`'(-w --work-dir)-w+[the working directory]:VALUE:_files -/'`,
+		`'(--json --yaml)--json[json output]'`,
+		`'(--yaml --json)--yaml[yaml output]'`,
+		"_describe -t commands 'comp-app server commands' commands",
+		"  compdef _comp_app comp-app\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("expecting %q in the zsh completion:\n%v", s, out)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Fatalf("the hidden command should not be completed:\n%v", out)
+	}
+}
+
+func TestGenShellFish(t *testing.T) {
+	defer resetOsArgs()
+
+	out := execShellGen(t, "gen", "shell", "--fish")
+	for _, s := range []string{
+		"complete -c comp-app -e\n",
+		"complete -c comp-app -f -n '__fish_use_subcommand' -a 's' -d 'server operations [ops]'\n",
+		"complete -c comp-app -f -n '__fish_use_subcommand' -a 'server' -d 'server operations [ops]'\n",
+		"complete -c comp-app -f -n '__fish_seen_subcommand_from s server; and not __fish_seen_subcommand_from st start stop' -a 'st' -d 'start the server'\n",
+		"complete -c comp-app -n '__fish_seen_subcommand_from s server' -s p -l port -x -d 'the listening port'\n",
+		"complete -c comp-app -n '__fish_seen_subcommand_from s server' -l level -x -a 'debug info warn' -d 'the log level'\n",
+		"complete -c comp-app -n '__fish_seen_subcommand_from s server' -l pid-file -r -F -d 'the pid file'\n",
+		"-s w -l work-dir -x -a '(__fish_complete_directories)' -d 'the working directory'\n",
+		"complete -c comp-app -l json -d 'json output'\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("expecting %q in the fish completion:\n%v", s, out)
+		}
+	}
+	if strings.Contains(out, "secret") || strings.Contains(out, "# comp-app server start\n") {
+		t.Fatalf("unexpected completions:\n%v", out)
+	}
+}
+
+func TestGenShellPowershell(t *testing.T) {
+	defer resetOsArgs()
+
+	out := execShellGen(t, "gen", "shell", "--powershell")
+	for _, s := range []string{
+		"Register-ArgumentCompleter -Native -CommandName 'comp-app' -ScriptBlock {\n",
+		`        'server' = @(
+            @{ Name = 'st'; Tip = 'start the server' }
+            @{ Name = 'start'; Tip = 'start the server' }
+            @{ Name = 'stop'; Tip = 'stop the server' }
+        )
+        'server start' = @(
+        )
+`,
+		`        'server start' = @(
+            @{ Name = '-p'; Tip = 'the listening port'; Value = $true; Values = @() }
+            @{ Name = '--port'; Tip = 'the listening port'; Value = $true; Values = @() }
+            @{ Name = '--level'; Tip = 'the log level'; Value = $true; Values = @('debug', 'info', 'warn') }
+            @{ Name = '--pid-file'; Tip = 'the pid file'; Value = $true; Values = @() }
+            @{ Name = '-w'; Tip = 'the working directory'; Value = $true; Values = @() }
+            @{ Name = '--work-dir'; Tip = 'the working directory'; Value = $true; Values = @() }
+            @{ Name = '--json'; Tip = 'json output'; Value = $false; Values = @() }
+            @{ Name = '--yaml'; Tip = 'yaml output'; Value = $false; Values = @() }
+`,
+		`        '|s' = 'server'
+        '|server' = 'server'
+`,
+		`        'server|st' = 'server start'
+        'server|start' = 'server start'
+        'server|stop' = 'server stop'
+`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("expecting %q in the powershell completion:\n%v", s, out)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Fatalf("the hidden command should not be completed:\n%v", out)
+	}
+}
+
+func TestGenShellBash(t *testing.T) {
+	defer resetOsArgs()
+
+	out := execShellGen(t, "gen", "shell", "--bash")
+	for _, s := range []string{
+		"_comp_app_completion() {\n",
+		"    ':s'|':server') path='server' ;;\n",
+		"    'server:st'|'server:start') path='server start' ;;\n",
+		"    'server:-p'|'server '*:'-p'|'server:--port'|'server '*:'--port') skip=1 ;;\n",
+		"    'server:--level'|'server '*:'--level')\n      COMPREPLY=($(compgen -W 'debug info warn' -- \"$cur\"))\n",
+		"    'server:-w'|'server '*:'-w'|'server:--work-dir'|'server '*:'--work-dir')\n      COMPREPLY=($(compgen -d -- \"$cur\"))\n",
+		"    'server:--pid-file'|'server '*:'--pid-file')\n      COMPREPLY=($(compgen -f -- \"$cur\"))\n",
+		"    'server') words='st start stop' ;;\n",
+		"    '') words='s server g generate gen' ;;\n",
+		"complete -o default -F _comp_app_completion comp-app\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("expecting %q in the bash completion:\n%v", s, out)
+		}
+	}
+	if strings.Contains(out, "secret") || strings.Contains(out, "--help") {
+		t.Fatalf("the hidden items should not be completed:\n%v", out)
+	}
+}
+
+func TestGenShellDynamic(t *testing.T) {
+	defer resetOsArgs()
+
+	for _, c := range []struct {
+		args    []string
+		expects []string
+	}{
+		{[]string{"gen", "shell", "--bash", "--dynamic"},
+			[]string{`out="$("${words[0]}" __complete "${words[@]:1}" 2>/dev/null)"`, "complete -o default -F _comp_app_complete comp-app\n"}},
+		{[]string{"gen", "shell", "--zsh", "--dynamic"},
+			[]string{"#compdef comp-app\n", `lines=("${(@f)$(${words[1]} __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")`, "  compdef _comp_app comp-app\n"}},
+		{[]string{"gen", "shell", "--fish", "--dynamic"},
+			[]string{`set -l out (command $cmd __complete $args "$cur" 2>/dev/null)`, "complete -c comp-app -f -a '(_comp_app_complete)'\n"}},
+	} {
+		out := execShellGen(t, c.args...)
+		for _, s := range c.expects {
+			if !strings.Contains(out, s) {
+				t.Fatalf("%v: expecting %q in the script:\n%v", c.args, s, out)
+			}
+		}
+	}
+}
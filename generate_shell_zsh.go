@@ -0,0 +1,181 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// zshCompletion generates the zsh completion script, one function for
+// each command, which completes the flags by `_arguments` and the
+// subcommands by `_describe`.
+func zshCompletion(root *RootCommand) string {
+	var sb strings.Builder
+	app := root.AppName
+	fn := shellFuncName(app, nil)
+
+	sb.WriteString(fmt.Sprintf(`#compdef %v
+
+# zsh completion for %v %v
+#
+# Save this script as '%v' into a directory in $fpath, for example:
+#
+#   mkdir -p ~/.zsh/completions
+#   %v generate shell --zsh > ~/.zsh/completions/%v
+#
+# and add these lines to ~/.zshrc if that directory is not in $fpath:
+#
+#   fpath=(~/.zsh/completions $fpath)
+#   autoload -U compinit && compinit
+#
+# Or just source it in the current shell.
+`, app, app, root.Version, fn, app, fn))
+
+	for _, sc := range shellCommands(&root.Command) {
+		sb.WriteString("\n")
+		zshFunction(&sb, app, sc)
+	}
+
+	sb.WriteString(fmt.Sprintf(`
+if [ "$funcstack[1]" = "%v" ]; then
+  %v "$@"
+else
+  compdef %v %v
+fi
+`, fn, fn, fn, app))
+	return sb.String()
+}
+
+func zshFunction(sb *strings.Builder, app string, sc *shellCommand) {
+	fn := shellFuncName(app, sc.path)
+	specs := zshFlagSpecs(sc.flags)
+
+	if len(sc.subs) == 0 {
+		sb.WriteString(fmt.Sprintf("%v() {\n  _arguments \\\n", fn))
+		for _, s := range specs {
+			sb.WriteString(fmt.Sprintf("    %v \\\n", s))
+		}
+		sb.WriteString("    '*: :_default'\n}\n")
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf(`%v() {
+  local curcontext="$curcontext" state line ret=1
+  typeset -A opt_args
+
+  _arguments -C \
+`, fn))
+	for _, s := range specs {
+		sb.WriteString(fmt.Sprintf("    %v \\\n", s))
+	}
+	sb.WriteString(`    ': :->commands' \
+    '*:: :->args' && ret=0
+
+  case $state in
+    commands)
+      local -a commands
+      commands=(
+`)
+	for _, cc := range sc.subs {
+		for _, n := range cc.GetTitleNamesArray() {
+			sb.WriteString(fmt.Sprintf("        %v\n", zshQuote(zshEscape(n, ":")+":"+shellDesc(&cc.BaseOpt))))
+		}
+	}
+	title := strings.Join(append([]string{app}, sc.path...), " ")
+	sb.WriteString(fmt.Sprintf(`      )
+      _describe -t commands %v commands && ret=0
+      ;;
+    args)
+      case $line[1] in
+`, zshQuote(title+" commands")))
+	for _, cc := range sc.subs {
+		p := append(append([]string{}, sc.path...), cc.GetTitleName())
+		sb.WriteString(fmt.Sprintf("        (%v)\n          %v && ret=0\n          ;;\n",
+			strings.Join(cc.GetTitleNamesArray(), "|"), shellFuncName(app, p)))
+	}
+	sb.WriteString(`      esac
+      ;;
+  esac
+
+  return ret
+}
+`)
+}
+
+// zshFlagSpecs returns the `_arguments` specs of the flags, each name
+// of a flag has its own spec. The names of a flag and the flags in the
+// same toggle group are mutually exclusive.
+func zshFlagSpecs(flags []*Flag) (specs []string) {
+	names := func(flg *Flag) (a []string) {
+		for _, n := range flg.GetShortTitleNamesArray() {
+			a = append(a, "-"+n)
+		}
+		for _, n := range flg.GetLongTitleNamesArray() {
+			a = append(a, "--"+n)
+		}
+		return
+	}
+
+	for _, flg := range flags {
+		exclusive := names(flg)
+		if len(flg.ToggleGroup) > 0 {
+			for _, f := range flags {
+				if f != flg && f.ToggleGroup == flg.ToggleGroup {
+					exclusive = append(exclusive, names(f)...)
+				}
+			}
+		}
+
+		var action string
+		if shellFlagTakesValue(flg) {
+			action = ":" + zshEscape(shellFlagPlaceholder(flg), ":") + ":"
+			if len(flg.ValidArgs) > 0 {
+				var values []string
+				for _, v := range flg.ValidArgs {
+					values = append(values, zshEscape(v, " ():'\""))
+				}
+				action += "(" + strings.Join(values, " ") + ")"
+			} else {
+				switch shellFlagPathKind(flg) {
+				case "dir":
+					action += "_files -/"
+				case "file":
+					action += "_files"
+				}
+			}
+		}
+
+		desc := "[" + zshEscape(shellDesc(&flg.BaseOpt), "[]") + "]"
+		for _, n := range names(flg) {
+			suffix := ""
+			if len(action) > 0 {
+				if strings.HasPrefix(n, "--") {
+					suffix = "="
+				} else {
+					suffix = "+"
+				}
+			}
+			specs = append(specs, zshQuote("("+strings.Join(exclusive, " ")+")"+n+suffix+desc+action))
+		}
+	}
+	return
+}
+
+// zshEscape escapes the backslashes and the special chars with
+// backslashes.
+func zshEscape(s, chars string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if r == '\\' || strings.ContainsRune(chars, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// zshQuote quotes s as a single-quoted shell word.
+func zshQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
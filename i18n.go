@@ -0,0 +1,129 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	// translations holds the message catalog: locale -> msgid -> text.
+	// The msgid is the original text (Description, LongDescription,
+	// Examples, the built-in strings, ...) or any id you like.
+	translations = map[string]map[string]string{
+		"zh-CN": builtinZhCN,
+	}
+	translationsRW sync.RWMutex
+)
+
+// AddTranslations merges a translation table into the message catalog
+// for the locale, such as "zh-CN", "zh" or "en".
+//
+// The keys of table are the message ids. A message id can be the
+// original text of a Description/LongDescription/Examples of your
+// commands and flags, so that the translation is attached to them
+// automatically:
+//
+//     cmdr.AddTranslations("zh-CN", map[string]string{
+//         "deploy the services": "部署服务",
+//     })
+//
+// Or you may use the ids as the Description and provide the english
+// texts with locale "en".
+func AddTranslations(locale string, table map[string]string) {
+	locale = normalizeLocale(locale)
+
+	translationsRW.Lock()
+	defer translationsRW.Unlock()
+
+	m, ok := translations[locale]
+	if !ok {
+		m = make(map[string]string, len(table))
+		translations[locale] = m
+	}
+	for k, v := range table {
+		m[k] = v
+	}
+}
+
+// T translates a message id to the current locale, see GetLocale.
+//
+// The lookup falls back to the language (zh-TW -> zh), then to "en",
+// and finally returns msgid itself. The result will be formatted by
+// fmt.Sprintf if any args given.
+func T(msgid string, args ...interface{}) (text string) {
+	text = TL(GetLocale(), msgid)
+	if len(args) > 0 {
+		text = fmt.Sprintf(text, args...)
+	}
+	return
+}
+
+// TL translates a message id to the given locale.
+func TL(locale, msgid string) string {
+	if len(msgid) == 0 {
+		return msgid
+	}
+
+	translationsRW.RLock()
+	defer translationsRW.RUnlock()
+
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if i := strings.Index(locale, "-"); i > 0 {
+		candidates = append(candidates, locale[:i])
+	}
+	candidates = append(candidates, "en")
+	for _, l := range candidates {
+		if text, ok := translations[l][msgid]; ok {
+			return text
+		}
+	}
+	return msgid
+}
+
+// GetLocale returns the current locale, such as "zh-CN", "en-US".
+//
+// It comes from, in order: the option `app.locale` (the `--locale`
+// flag or the config files), WithLocale(), the environment variables
+// LC_ALL, LC_MESSAGES and LANG. The default is "en".
+func GetLocale() (locale string) {
+	w := internalGetWorker()
+	if w.rxxtOptions != nil {
+		locale = GetStringR("locale")
+	}
+	if len(locale) == 0 {
+		locale = w.locale
+	}
+	if len(locale) == 0 {
+		for _, k := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+			if locale = os.Getenv(k); len(locale) > 0 {
+				break
+			}
+		}
+	}
+	if locale = normalizeLocale(locale); len(locale) == 0 {
+		locale = "en"
+	}
+	return
+}
+
+// normalizeLocale converts the posix locale names to the BCP 47 style,
+// such as "zh_CN.UTF-8" -> "zh-CN".
+func normalizeLocale(locale string) string {
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	if locale == "C" || locale == "POSIX" {
+		return "en"
+	}
+	parts := strings.Split(replaceAll(locale, "_", "-"), "-")
+	parts[0] = strings.ToLower(parts[0])
+	if len(parts) > 1 && len(parts[1]) == 2 {
+		parts[1] = strings.ToUpper(parts[1])
+	}
+	return strings.Join(parts, "-")
+}
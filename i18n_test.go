@@ -0,0 +1,76 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestTranslations(t *testing.T) {
+	defer cmdr.SaveTranslations()()
+
+	cmdr.AddTranslations("zh_TW.UTF-8", map[string]string{"deploy the services": "部署服務"})
+	cmdr.AddTranslations("de", map[string]string{"deploy the services": "Dienste bereitstellen"})
+
+	for _, c := range []struct{ locale, expect string }{
+		{"zh-TW", "部署服務"},
+		{"de-AT", "Dienste bereitstellen"},
+		{"fr", "deploy the services"},
+		{"C", "deploy the services"},
+	} {
+		if s := cmdr.TL(c.locale, "deploy the services"); s != c.expect {
+			t.Fatalf("TL(%q): expecting %q but got %q", c.locale, c.expect, s)
+		}
+	}
+	if s := cmdr.TL("zh_CN.UTF-8", "Usages"); s != "用法" {
+		t.Fatalf("the builtin translation not found, got %q", s)
+	}
+}
+
+func TestLocalizedHelpScreen(t *testing.T) {
+	defer resetOsArgs()
+	defer cmdr.SaveTranslations()()
+
+	root := &cmdr.RootCommand{
+		Command: cmdr.Command{
+			BaseOpt: cmdr.BaseOpt{Name: "i18n-app"},
+			SubCommands: []*cmdr.Command{{
+				BaseOpt: cmdr.BaseOpt{Full: "deploy", Description: "deploy the services"},
+			}},
+		},
+		AppName: "i18n-app",
+		Version: "1.0.0",
+	}
+	cmdr.AddTranslations("zh-CN", map[string]string{"deploy the services": "部署服务"})
+
+	for _, c := range []struct {
+		args    []string
+		locale  string
+		expects []string
+	}{
+		{[]string{"i18n-app", "--help"}, "zh_CN.UTF-8", []string{"用法", "部署服务", "[选项]"}},
+		{[]string{"i18n-app", "--help", "--locale", "en"}, "zh_CN.UTF-8", []string{"Usages", "deploy the services"}},
+	} {
+		cmdr.ResetOptions()
+		cmdr.InternalResetWorker()
+		outX, _ := prepareStreams()
+
+		os.Args = c.args
+		if err := cmdr.Exec(root, cmdr.WithLocale(c.locale), cmdr.WithNoLoadConfigFiles(true), cmdr.WithNoColor(true)); err != nil {
+			t.Fatal(err)
+		}
+		cmdr.InternalResetWorker() // flush the outputs
+
+		out := outX.String()
+		for _, s := range c.expects {
+			if !strings.Contains(out, s) {
+				t.Fatalf("%v: expecting %q in help screen:\n%v", c.args, s, out)
+			}
+		}
+	}
+	cmdr.ResetOptions()
+}
@@ -0,0 +1,162 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+// builtinZhCN is the simplified chinese translations of the built-in
+// commands, flags, section titles and messages.
+var builtinZhCN = map[string]string{
+	// help screen
+	"Usages":                            "用法",
+	"Description":                       "描述",
+	"Examples":                          "示例",
+	"Options":                           "选项",
+	"Global Options":                    "全局选项",
+	"Parent (`%v`) Options":             "上级命令 (`%v`) 选项",
+	"Commands":                          "命令",
+	"Sub-Commands":                      "子命令",
+	"[Commands] ":                       "[命令] ",
+	"[Sub-Commands] ":                   "[子命令] ",
+	"[Sub-Commands]":                    "[子命令]",
+	"[tail args...]":                    "[尾部参数...]",
+	"[Options]":                         "[选项]",
+	"[Options] [Parent/Global Options]": "[选项] [上级/全局选项]",
+	"Misc":                              "杂项",
+	"General":                           "通用",
+	"Command":                           "命令",
+	"Flag":                              "选项",
+	"Aliases":                           "别名",
+	"Short":                             "短名",
+	"Default":                           "缺省值",
+	"Names: %v":                         "名称：%v",
+	"One of: %v":                        "可选值：%v",
+	"See also: %v":                      "参见：%v",
+	"See Also":                          "参见",
+	"deprecated since %v":               "自 %v 起已废弃",
+	"Warning:":                          "警告：",
+	"%q is deprecated since %v":         "%q 自 %v 起已废弃",
+	" and will be removed in %v":        "，并将在 %v 中移除",
+	", use %q instead":                  "，请改用 %q",
+	"%q has been removed in %v.":        "%q 已在 %v 中移除。",
+	"%q has been removed in %v, use %q instead.":                                 "%q 已在 %v 中移除，请改用 %q。",
+	"%d problem(s) found in the command tree:":                                   "命令树中发现 %d 个问题：",
+	"No problems found.":                                                         "没有发现问题。",
+	"the flag has neither a short name nor a long name":                          "该选项既没有短名称也没有长名称",
+	"only one head-like flag is allowed in a command chain, %q is head-like too": "一个命令链中只允许一个 head-like 选项，%q 也是 head-like 选项",
+	"the default value %q isn't one of the valid args %v":                        "缺省值 %q 不在有效值 %v 之中",
+	"the flag is replaced by itself":                                             "该选项被自身替代",
+	"flag":                                                                       "选项",
+	"command":                                                                    "命令",
+	"a sub-command has no name":                                                  "某个子命令没有名称",
+	"the %v name %q of %q conflicts with %q":                                     "%[3]q 的%[1]v名称 %[2]q 与 %[4]q 冲突",
+	"removed in %v, which isn't newer than deprecated since %v":                  "移除版本 %v 不晚于废弃版本 %v",
+	"the format of --tree: text, json, dot or mermaid":                           "--tree 的输出格式：text、json、dot 或 mermaid",
+	"show the flags in --tree":                                                   "在 --tree 中显示选项",
+	"show the hidden commands and flags in --tree":                               "在 --tree 中显示隐藏的命令和选项",
+	"show the commands of the group only in --tree":                              "在 --tree 中只显示该分组的命令",
+	"hidden": "隐藏",
+	"Check the examples of all commands and flags against the command tree.": "根据命令树检查所有命令和选项的示例。",
+	"example %q: %v":     "示例 %q：%v",
+	"unknown command %q": "未知的命令 %q",
+	"unknown flag %q":    "未知的选项 %q",
+	"unexpected value %q for %v, expecting one of %v":                         "%[2]v 的值 %[1]q 无效，应为 %[3]v 之一",
+	"Check the command tree for the conflicted names and the other mistakes.": "检查命令树中的名称冲突和其它错误。",
+	"Search results for %q:":                                                  "%q 的搜索结果：",
+	"No matches for %q.":                                                      "没有找到与 %q 匹配的结果。",
+	"Nothing to search, try: --help-search TERM":                              "没有要搜索的内容，请尝试：--help-search TERM",
+	"name":             "名称",
+	"description":      "描述",
+	"long description": "详细描述",
+	"examples":         "示例",
+	"env":              "环境变量",
+	"config":           "配置项",
+	"value":            "值",
+	"Overrides %v":     "覆盖 %v",
+	defaultTailLine: `
+输入 '-h'/'-?' 或 '--help' 以显示命令的帮助屏幕。
+更多：'-D'/'--debug'['--env'|'--raw'|'--more']、'-V'/'--version'、'-#'/'--build-info'、'--no-color'、'--strict-mode'、'--no-env-overrides'...`,
+
+	// man page and markdown sections
+	"NAME":                      "名称",
+	"SYNOPSIS":                  "概要",
+	"DESCRIPTIONS":              "描述",
+	"EXAMPLES":                  "示例",
+	"OPTIONS":                   "选项",
+	"COMMANDS AND SUB-COMMANDS": "命令和子命令",
+	"SUB-COMMANDS":              "子命令",
+	"SUBCOMMANDS":               "子命令",
+	"ENVIRONMENT":               "环境变量",
+	"FILES":                     "文件",
+	"SEE ALSO":                  "参见",
+	"HISTORY":                   "历史",
+	"The first config file found will be loaded, and the files in the \\fIconf.d\\fP directory beside it will be merged too.": "将会载入找到的第一个配置文件，与其同级的 \\fIconf.d\\fP 目录中的文件也会被合并进来。",
+
+	// errors
+	"Unknown command:": "未知的命令：",
+	"Unknown flag:":    "未知的选项：",
+	"do you mean: %v":  "您是不是想要：%v",
+
+	// built-in commands and flags
+	"Show the version of this app.":                                                    "显示本程序的版本号。",
+	"Simulate a faked version number for this app.":                                    "为本程序模拟一个假的版本号。",
+	"Show the building information of this app.":                                       "显示本程序的构建信息。",
+	"Show this help screen":                                                            "显示本帮助屏幕",
+	"show help with zsh format, or others":                                             "以 zsh 或其它格式显示帮助",
+	"show help with bash format, or others":                                            "以 bash 或其它格式显示帮助",
+	"search the commands and flags by name, description, examples or env var":          "按名称、描述、示例或环境变量搜索命令和选项",
+	"show the env vars, config key and effective value of each flag in help screen":    "在帮助屏幕中显示每个选项的环境变量、配置项和有效值",
+	"show a tree for all commands":                                                     "以树形显示全部命令",
+	"load the env vars from a dotenv file":                                             "从 dotenv 文件载入环境变量",
+	"load config files from where you specified":                                       "从指定的位置载入配置文件",
+	"No more screen output.":                                                           "不再输出屏幕信息。",
+	"Get into debug mode.":                                                             "进入调试模式。",
+	"Dump environment info in `~~debug` mode.":                                         "在 `~~debug` 模式中转储环境信息。",
+	"Dump the option value in raw mode (with golang data structure).":                  "以原始模式（golang 数据结构）转储选项值。",
+	"Dump more info in `~~debug` mode.":                                                "在 `~~debug` 模式中转储更多信息。",
+	"strict mode for `cmdr`.":                                                          "`cmdr` 的严格模式。",
+	"No env var overrides for `cmdr`.":                                                 "`cmdr` 不使用环境变量覆盖选项。",
+	"No color output for `cmdr`.":                                                      "`cmdr` 不输出彩色信息。",
+	"Don't pipe the long outputs through $PAGER.":                                      "不要通过 $PAGER 分页显示长的输出。",
+	"The locale of help screen and messages, such as 'zh-CN'.":                         "帮助屏幕和消息的语言区域，例如 'zh-CN'。",
+	"generators for this app.":                                                         "本程序的生成器。",
+	"generate the bash/zsh/fish/powershell auto-completion script or install it.":      "生成或安装 bash/zsh/fish/powershell 自动补全脚本。",
+	"generate auto completion script for PowerShell":                                   "为 PowerShell 生成自动补全脚本",
+	"generate a script which completes by calling the app, for the dynamic values":     "生成调用应用本身进行补全的脚本，用于补全动态的值",
+	"return the completion candidates of the words, for the shell completion scripts.": "返回命令行的补全候选项，供 shell 补全脚本调用。",
+	"install the completion script for the current user":                               "为当前用户安装补全脚本",
+	"remove the installed completion script":                                           "移除已安装的补全脚本",
+	"show what would be changed by --install or --uninstall":                           "显示 --install 或 --uninstall 将会做出的改动",
+	"install into this directory instead of the user-level one, for packaging":         "安装到此目录而非用户目录，用于打包",
+	"Unknown shell, try --bash, --zsh, --fish or --powershell.":                        "未知的 shell，请尝试 --bash, --zsh, --fish 或 --powershell。",
+	"The %v completion is not installed: %v":                                           "%v 补全脚本未安装：%v",
+	"Would remove the %v completion: %v":                                               "将移除 %v 补全脚本：%v",
+	"Removed the %v completion: %v":                                                    "已移除 %v 补全脚本：%v",
+	"The %v completion is up to date: %v":                                              "%v 补全脚本已是最新：%v",
+	"Would update the %v completion: %v":                                               "将更新 %v 补全脚本：%v",
+	"Would install the %v completion: %v":                                              "将安装 %v 补全脚本：%v",
+	"Updated the %v completion: %v":                                                    "已更新 %v 补全脚本：%v",
+	"Installed the %v completion: %v":                                                  "已安装 %v 补全脚本：%v",
+	"Add %q to ~/.zshrc before compinit if the directory is not in $fpath.":            "如果该目录不在 $fpath 中，请在 ~/.zshrc 的 compinit 之前添加 %q。",
+	"Add %q to your $PROFILE to load it.":                                              "请在 $PROFILE 中添加 %q 以加载它。",
+	"Start a new shell to take effect.":                                                "打开新的 shell 以生效。",
+	"generate auto completion script for Fish":                                         "为 Fish 生成自动补全脚本",
+	"generate auto completion script for Bash":                                         "为 Bash 生成自动补全脚本",
+	"generate auto completion script for Zsh":                                          "为 Zsh 生成自动补全脚本",
+	"generate auto completion script to fit for your current env.":                     "按当前环境生成自动补全脚本。",
+	"just for --auto":                                                "仅用于 --auto",
+	"generate linux man page.":                                       "生成 linux 手册页。",
+	"the output directory":                                           "输出目录",
+	"generate one man page for all commands":                         "为全部命令生成一个手册页",
+	"compress the man pages with gzip (.1.gz)":                       "以 gzip 压缩手册页 (.1.gz)",
+	"generate a markdown document, or: pdf/TeX/html/...":             "生成 markdown 文档，或者：pdf/TeX/html/...",
+	"generate mardown":                                               "生成 markdown",
+	"generate pdf":                                                   "生成 pdf",
+	"generate word doc":                                              "生成 word 文档",
+	"generate a word document (.docx)":                               "生成 word 文档 (.docx)",
+	"generate a static html site":                                    "生成静态 html 站点",
+	"generate all commands into one page, for --html and --markdown": "将全部命令生成到一个页面中，用于 --html 和 --markdown",
+	"generate a LaTeX document":                                      "生成 LaTeX 文档",
+	"generate the go source codes of a command tree from a yaml/json spec file.": "从 yaml/json 描述文件生成命令树的 go 源代码。",
+	"the command spec file (.yml/.yaml/.json)":                                   "命令描述文件 (.yml/.yaml/.json)",
+	"the package name, default is $GOPACKAGE or 'main'":                          "包名，缺省为 $GOPACKAGE 或 'main'",
+	"the name of the generated function":                                         "生成的函数名",
+}
@@ -0,0 +1,122 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compares two version strings such as "v1.6.9",
+// "1.7" or "2.0.0-rc1", it returns -1, 0 or 1 if a is older than,
+// equal to or newer than b. A pre-release is older than its release.
+func CompareVersions(a, b string) int {
+	va, pa := splitVersion(a)
+	vb, pb := splitVersion(b)
+	for i := 0; i < len(va) || i < len(vb); i++ {
+		var x, y int
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case pa == pb:
+		return 0
+	case len(pa) == 0:
+		return 1
+	case len(pb) == 0 || pa < pb:
+		return -1
+	}
+	return 1
+}
+
+// splitVersion returns the numeric parts and the pre-release part of
+// a version string, the build metadata is ignored.
+func splitVersion(s string) (parts []int, pre string) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, pre = s[:i], s[i+1:]
+	}
+	for _, p := range strings.Split(s, ".") {
+		n, _ := strconv.Atoi(p)
+		parts = append(parts, n)
+	}
+	return
+}
+
+// lifecycleOf returns the states of a command or flag by comparing
+// its Deprecated and RemovedIn with the version of the app. An item
+// is deprecated when the app has no version, or the version reaches
+// Deprecated; it is removed when the version reaches RemovedIn.
+func (w *ExecWorker) lifecycleOf(opt *BaseOpt) (deprecated, removed bool) {
+	var version string
+	if w.rootCommand != nil {
+		version = w.rootCommand.Version
+	}
+	if len(opt.RemovedIn) > 0 && len(version) > 0 && CompareVersions(version, opt.RemovedIn) >= 0 {
+		return true, true
+	}
+	deprecated = len(opt.Deprecated) > 0 && (len(version) == 0 || CompareVersions(version, opt.Deprecated) >= 0)
+	return
+}
+
+// removedError reports a removed command or flag, InternalExecFor
+// stops at it without invoking any action.
+type removedError struct {
+	msg string
+}
+
+func (e *removedError) Error() string { return e.msg }
+
+// checkLifecycle returns a removedError if the command or flag named
+// title has been removed, or warns on stderr if it's deprecated.
+func (w *ExecWorker) checkLifecycle(opt *BaseOpt, title, replacement string) (err error) {
+	deprecated, removed := w.lifecycleOf(opt)
+	if removed {
+		if len(replacement) > 0 {
+			return &removedError{T("%q has been removed in %v, use %q instead.", title, opt.RemovedIn, replacement)}
+		}
+		return &removedError{T("%q has been removed in %v.", title, opt.RemovedIn)}
+	}
+	if !deprecated || GetQuietMode() {
+		return
+	}
+
+	msg := T("%q is deprecated since %v", title, opt.Deprecated)
+	if len(opt.RemovedIn) > 0 {
+		msg += T(" and will be removed in %v", opt.RemovedIn)
+	}
+	if len(replacement) > 0 {
+		msg += T(", use %q instead", replacement)
+	}
+	if GetNoColorMode() || w.noColorStderr {
+		ferr("%v %v.", T("Warning:"), msg)
+	} else {
+		ferr("\x1b[%dm\x1b[%dm%v\x1b[0m %v.", BgBoldOrBright, FgYellow, T("Warning:"), msg)
+	}
+	return
+}
+
+func (w *ExecWorker) checkFlagLifecycle(flg *Flag) (err error) {
+	var replacement string
+	if flg.ReplacedBy != nil {
+		replacement = flg.ReplacedBy.GetTitleZshFlagName()
+	}
+	return w.checkLifecycle(&flg.BaseOpt, flg.GetTitleZshFlagName(), replacement)
+}
+
+func (w *ExecWorker) checkCommandLifecycle(cmd *Command) (err error) {
+	return w.checkLifecycle(&cmd.BaseOpt, replaceAll(w.backtraceCmdNames(cmd), ".", " "), "")
+}
@@ -0,0 +1,84 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestCompareVersions(t *testing.T) {
+	for _, c := range []struct {
+		a, b   string
+		expect int
+	}{
+		{"v1.6.9", "1.6.9", 0},
+		{"1.7", "1.6.10", 1},
+		{"1.6", "1.6.0", 0},
+		{"v2.0.0-rc1", "2.0.0", -1},
+		{"2.0.0-rc2", "2.0.0-rc1", 1},
+		{"1.10.0", "1.9.9", 1},
+		{"1.2.3+build5", "1.2.3", 0},
+	} {
+		if r := cmdr.CompareVersions(c.a, c.b); r != c.expect {
+			t.Fatalf("CompareVersions(%q, %q): expecting %v but got %v", c.a, c.b, c.expect, r)
+		}
+	}
+}
+
+func TestDeprecationLifecycle(t *testing.T) {
+	defer resetOsArgs()
+
+	var port int
+	root := cmdr.Root("lifecycle-app", "1.5.0")
+	server := root.NewSubCommand("server", "s").
+		Description("server operations", "").
+		Action(func(cmd *cmdr.Command, args []string) (err error) {
+			port = cmdr.GetIntR("server.port")
+			return
+		})
+	newFlag := server.NewFlagV(8080, "port", "p").Description("the listening port", "")
+	server.NewFlagV(8080, "listen").
+		Description("the listening port", "").
+		Deprecated("1.2.0").RemovedIn("2.0.0").
+		ReplacedBy(newFlag)
+	server.NewFlagV(false, "legacy-tls").Deprecated("1.6.0")
+	server.NewFlagV(false, "insecure").Deprecated("1.0.0").RemovedIn("1.5.0")
+	root.NewSubCommand("legacy").Deprecated("1.0.0").RemovedIn("v1.4")
+
+	for _, c := range []struct {
+		args    []string
+		fails   bool
+		port    int
+		warning string
+	}{
+		{[]string{"lifecycle-app", "server", "--listen", "9090"}, false, 9090,
+			`Warning: "--listen" is deprecated since 1.2.0 and will be removed in 2.0.0, use "--port" instead.`},
+		{[]string{"lifecycle-app", "server", "--legacy-tls"}, false, 8080, ""},
+		{[]string{"lifecycle-app", "server", "--insecure"}, true, 0, ""},
+		{[]string{"lifecycle-app", "legacy"}, true, 0, ""},
+	} {
+		cmdr.ResetOptions()
+		cmdr.InternalResetWorker()
+		_, errX := prepareStreams()
+
+		port = 0
+		os.Args = c.args
+		err := cmdr.Exec(root.RootCommand(), cmdr.WithNoLoadConfigFiles(true))
+		cmdr.InternalResetWorker() // flush the outputs
+
+		if (err != nil) != c.fails {
+			t.Fatalf("%v: expecting failure %v, but got err = %v", c.args, c.fails, err)
+		}
+		if port != c.port {
+			t.Fatalf("%v: expecting port %v but got %v", c.args, c.port, port)
+		}
+		if out := errX.String(); !strings.Contains(out, c.warning) || (len(c.warning) == 0 && strings.Contains(out, "Warning:")) {
+			t.Fatalf("%v: expecting warning %q, but got:\n%v", c.args, c.warning, out)
+		}
+	}
+	cmdr.ResetOptions()
+}
@@ -0,0 +1,139 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError holds the problems found by Validate, each one is
+// prefixed with the path of the command or flag.
+type ValidationError struct {
+	Issues []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%v\n  %v", T("%d problem(s) found in the command tree:", len(e.Issues)),
+		strings.Join(e.Issues, "\n  "))
+}
+
+// Validate checks the command tree and returns a *ValidationError for
+// the mistakes which are accepted silently by Exec(), such as:
+//
+//  - two flags or sibling commands sharing a name, a short name or an alias,
+//  - more than one HeadLike flag in a command chain,
+//  - the default value of a flag isn't one of its ValidArgs,
+//  - RemovedIn isn't newer than Deprecated.
+//
+// It's suitable for running in a unit test:
+//
+//     func TestCommandTree(t *testing.T) {
+//         if err := cmdr.Validate(buildRootCmd()); err != nil {
+//             t.Fatal(err)
+//         }
+//     }
+//
+// The same checks can be run by the hidden flag `~~lint`.
+func Validate(root *RootCommand) (err error) {
+	v := &validator{}
+	name := root.AppName
+	if len(name) == 0 {
+		name = root.Name
+	}
+	v.command(&root.Command, name, nil, "")
+	if len(v.issues) > 0 {
+		err = &ValidationError{Issues: v.issues}
+	}
+	return
+}
+
+type validator struct {
+	issues []string
+}
+
+func (v *validator) add(path, format string, args ...interface{}) {
+	v.issues = append(v.issues, path+": "+T(format, args...))
+}
+
+// command checks cmd and its descendants, headLike is the HeadLike
+// flag found in the ancestors.
+func (v *validator) command(cmd *Command, path string, headLike *Flag, headLikePath string) {
+	v.lifecycle(&cmd.BaseOpt, path)
+
+	shorts, longs := make(map[string]namedItem), make(map[string]namedItem)
+	for _, flg := range cmd.Flags {
+		title := flg.GetTitleZshFlagName()
+		if len(title) == 0 {
+			title = flg.Name
+		}
+		flagPath := path + " " + title
+		if len(flg.Short) == 0 && len(flg.Full) == 0 {
+			v.add(flagPath, "the flag has neither a short name nor a long name")
+		}
+		v.unique(shorts, path, "flag", namedItem{flg, title}, flg.Short)
+		v.unique(longs, path, "flag", namedItem{flg, title}, append([]string{flg.Full}, flg.Aliases...)...)
+		v.lifecycle(&flg.BaseOpt, flagPath)
+
+		if flg.HeadLike {
+			if headLike != nil {
+				v.add(flagPath, "only one head-like flag is allowed in a command chain, %q is head-like too", headLikePath)
+			} else {
+				headLike, headLikePath = flg, flagPath
+			}
+		}
+		if dv, ok := flg.DefaultValue.(string); ok && len(dv) > 0 && len(flg.ValidArgs) > 0 && !contains(flg.ValidArgs, dv) {
+			v.add(flagPath, "the default value %q isn't one of the valid args %v", dv, flg.ValidArgs)
+		}
+		if flg.ReplacedBy == flg {
+			v.add(flagPath, "the flag is replaced by itself")
+		}
+	}
+
+	names := make(map[string]namedItem)
+	for _, cx := range cmd.SubCommands {
+		title := cx.GetTitleName()
+		if len(title) == 0 {
+			v.add(path, "a sub-command has no name")
+			continue
+		}
+		v.unique(names, path, "command", namedItem{cx, title}, append(cx.GetTitleNamesArray(), cx.Name)...)
+		v.command(cx, path+" "+title, headLike, headLikePath)
+	}
+}
+
+// namedItem is a *Command or a *Flag with its title.
+type namedItem struct {
+	item  interface{}
+	title string
+}
+
+// unique records the names of an item, and reports the names which
+// have been used by another item.
+func (v *validator) unique(used map[string]namedItem, path, kind string, it namedItem, names ...string) {
+	for _, n := range names {
+		if len(n) == 0 {
+			continue
+		}
+		if owner, ok := used[n]; ok && owner.item != it.item {
+			v.add(path, "the %v name %q of %q conflicts with %q", T(kind), n, it.title, owner.title)
+			continue
+		}
+		used[n] = it
+	}
+}
+
+func (v *validator) lifecycle(opt *BaseOpt, path string) {
+	if len(opt.Deprecated) > 0 && len(opt.RemovedIn) > 0 && CompareVersions(opt.RemovedIn, opt.Deprecated) <= 0 {
+		v.add(path, "removed in %v, which isn't newer than deprecated since %v", opt.RemovedIn, opt.Deprecated)
+	}
+}
+
+// lint is the action of `~~lint`.
+func lint(cmd *Command, args []string) (err error) {
+	if err = Validate(internalGetWorker().rootCommand); err != nil {
+		return
+	}
+	fp("%v", T("No problems found."))
+	return ErrShouldBeStopException
+}
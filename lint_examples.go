@@ -0,0 +1,205 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bufio"
+	"io/ioutil"
+	"strings"
+)
+
+// ValidateExamples checks the Examples of all commands and flags. The
+// lines beginning with `$ ` and the app name, such as:
+//
+//     $ {{.AppName}} server start --port 8080
+//
+// are parsed in a dry-run mode by the real parser, and the examples
+// referencing the unknown commands or flags, or the invalid enum
+// values, are returned in a *ValidationError. The actions are never
+// invoked in the dry-run mode. The other lines are ignored.
+//
+// It's suitable for running in a unit test, or by the hidden flag
+// `~~lint-examples` in CI.
+func ValidateExamples(root *RootCommand) (err error) {
+	v := &validator{}
+	name := root.AppName
+	if len(name) == 0 {
+		name = root.Name
+	}
+	v.examples(root, &root.Command, name)
+	if len(v.issues) > 0 {
+		err = &ValidationError{Issues: v.issues}
+	}
+	return
+}
+
+func (v *validator) examples(root *RootCommand, cmd *Command, path string) {
+	v.examplesOf(root, &cmd.BaseOpt, path)
+	for _, flg := range cmd.Flags {
+		v.examplesOf(root, &flg.BaseOpt, path+" "+flg.GetTitleZshFlagName())
+	}
+	for _, cx := range cmd.SubCommands {
+		v.examples(root, cx, path+" "+cx.GetTitleName())
+	}
+}
+
+func (v *validator) examplesOf(root *RootCommand, opt *BaseOpt, path string) {
+	if len(opt.Examples) == 0 {
+		return
+	}
+	for _, line := range strings.Split(tplApply(opt.Examples, root), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "$ ") {
+			continue
+		}
+		args := exampleArgs(line[2:])
+		if len(args) == 0 || args[0] != root.AppName {
+			continue
+		}
+		for _, issue := range dryRun(root, args, false, nil) {
+			v.add(path, "example %q: %v", line, issue)
+		}
+	}
+}
+
+// exampleArgs splits a shell command line into the arguments, the
+// quotes are removed, and a pipe, a redirection or a command
+// separator ends the line.
+func exampleArgs(line string) (args []string) {
+	var sb strings.Builder
+	var quote rune
+	var escaped, inArg bool
+	for _, r := range line {
+		switch {
+		case escaped:
+			sb.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				sb.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, sb.String())
+				sb.Reset()
+				inArg = false
+			}
+		case r == '|' || r == ';' || r == '>' || r == '<' || r == '&' || (r == '#' && !inArg):
+			if inArg {
+				args = append(args, sb.String())
+			}
+			return
+		default:
+			sb.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, sb.String())
+	}
+	return
+}
+
+// dryRun parses args by a temporary worker without invoking any
+// actions, and returns the problems found. If then is not nil, it's
+// called with the last parsed command before the worker is restored,
+// so that the options parsed from args can be read in it.
+//
+// The temporary worker takes the settings of the app, such as the
+// prefixes and the predefined locations, and it loads the config files
+// as Exec does if loadConfig is true. The flags of root are restored
+// after parsing, so the tree isn't changed by a dry run.
+func dryRun(root *RootCommand, args []string, loadConfig bool, then func(last *Command)) (issues []string) {
+	saved := internalGetWorker()
+	savedUnknownOptionHandler := unknownOptionHandler
+	savedOut, savedErr := root.ow, root.oerr
+	defer saveFlagStates(&root.Command)()
+	defer func() {
+		uniqueWorkerLock.Lock()
+		uniqueWorker = saved
+		unknownOptionHandler = savedUnknownOptionHandler
+		uniqueWorkerLock.Unlock()
+		root.ow, root.oerr = savedOut, savedErr
+	}()
+
+	uniqueWorkerLock.Lock()
+	w := internalResetWorkerNoLock()
+	uniqueWorkerLock.Unlock()
+
+	w.envPrefixes, w.rxxtPrefixes = saved.envPrefixes, saved.rxxtPrefixes
+	w.predefinedLocations = saved.predefinedLocations
+	w.envvarToValueMap = saved.envvarToValueMap
+	w.shouldIgnoreWrongEnumValue = saved.shouldIgnoreWrongEnumValue
+	w.enableVersionCommands, w.enableHelpCommands = saved.enableVersionCommands, saved.enableHelpCommands
+	w.enableVerboseCommands, w.enableCmdrCommands = saved.enableVerboseCommands, saved.enableCmdrCommands
+	w.enableGenerateCommands = saved.enableGenerateCommands
+	w.noEnvOverrides, w.strictMode, w.locale = saved.noEnvOverrides, saved.strictMode, saved.locale
+
+	noop := func(parsed *Command, switchChar string, args []string) (err error) { return }
+	w.defaultStdout = bufio.NewWriter(ioutil.Discard)
+	w.defaultStderr = bufio.NewWriter(ioutil.Discard)
+	w.doNotLoadingConfigFiles = !loadConfig || saved.doNotLoadingConfigFiles
+	w.doNotWatchingConfigFiles = true
+	w.noDefaultHelpScreen = true
+	w.noCommandAction = true
+	w.onSwitchCharHit, w.onPassThruCharHit = noop, noop
+	w.dryRun = true
+	unknownOptionHandler = emptyUnknownOptionHandler
+
+	_, err := w.InternalExecFor(root, args)
+	if then != nil {
+		last := w.dryRunCommand
+		if last == nil {
+			last = &root.Command
+		}
+		then(last)
+	}
+	issues = w.dryRunIssues
+	if err != nil && len(issues) == 0 {
+		issues = append(issues, err.Error())
+	}
+	return
+}
+
+// flagState is the state of a flag which is changed by parsing.
+type flagState struct {
+	defaultValue interface{}
+	times        int
+}
+
+// saveFlagStates returns a func to restore the flags of cmd and its
+// sub-commands to the current states.
+func saveFlagStates(cmd *Command) (restore func()) {
+	states := make(map[*Flag]flagState)
+	var walk func(c *Command)
+	walk = func(c *Command) {
+		for _, f := range c.Flags {
+			states[f] = flagState{f.DefaultValue, f.times}
+		}
+		for _, cx := range c.SubCommands {
+			walk(cx)
+		}
+	}
+	walk(cmd)
+
+	return func() {
+		for f, st := range states {
+			f.DefaultValue, f.times = st.defaultValue, st.times
+		}
+	}
+}
+
+// lintExamples is the action of `~~lint-examples`.
+func lintExamples(cmd *Command, args []string) (err error) {
+	if err = ValidateExamples(internalGetWorker().rootCommand); err != nil {
+		return
+	}
+	fp("%v", T("No problems found."))
+	return ErrShouldBeStopException
+}
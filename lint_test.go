@@ -0,0 +1,153 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestValidate(t *testing.T) {
+	root := cmdr.Root("lint-app", "1.0.0")
+	server := root.NewSubCommand("server", "s")
+	server.NewFlagV(8080, "port", "p")
+	server.NewFlagV("", "pid-file", "p")
+	server.NewFlagV(10, "lines", "n").HeadLike(true, 1, 100)
+	server.NewSubCommand("tail").NewFlagV(10, "count", "c").HeadLike(true, 1, 100)
+	server.NewFlagV("trace", "level").ValidArgs("debug", "info")
+	server.NewFlagV(false, "insecure").Deprecated("1.2.0").RemovedIn("1.1.0")
+	root.NewSubCommand("status", "st")
+	root.NewSubCommand("start", "st")
+	root.NewSubCommand("deploy").Aliases("server")
+	root.NewSubCommand("version")
+	root.NewSubCommand("version")
+	server.NewFlagV(9090, "port")
+
+	err := cmdr.Validate(root.RootCommand())
+	e, ok := err.(*cmdr.ValidationError)
+	if !ok {
+		t.Fatalf("expecting a *ValidationError, but got %v", err)
+	}
+	for _, s := range []string{
+		`lint-app server: the flag name "p" of "--pid-file" conflicts with "--port"`,
+		`lint-app server tail --count: only one head-like flag is allowed in a command chain, "lint-app server --lines" is head-like too`,
+		`lint-app server --level: the default value "trace" isn't one of the valid args [debug info]`,
+		`lint-app server --insecure: removed in 1.1.0, which isn't newer than deprecated since 1.2.0`,
+		`lint-app: the command name "st" of "start" conflicts with "status"`,
+		`lint-app: the command name "server" of "deploy" conflicts with "server"`,
+		`lint-app: the command name "version" of "version" conflicts with "version"`,
+		`lint-app server: the flag name "port" of "--port" conflicts with "--port"`,
+	} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expecting %q in the issues:\n%v", s, err)
+		}
+	}
+	if len(e.Issues) != 8 {
+		t.Fatalf("expecting 8 issues, but got:\n%v", err)
+	}
+
+	good := cmdr.Root("good-app", "1.0.0")
+	good.NewSubCommand("server", "s").NewFlagV("info", "level").ValidArgs("debug", "info")
+	if err = cmdr.Validate(good.RootCommand()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLintFlag(t *testing.T) {
+	defer resetOsArgs()
+
+	root := cmdr.Root("lint-app", "1.0.0")
+	root.NewSubCommand("status", "st")
+	root.NewSubCommand("start", "st")
+
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+	prepareStreams()
+
+	os.Args = []string{"lint-app", "~~lint"}
+	err := cmdr.Exec(root.RootCommand(), cmdr.WithNoLoadConfigFiles(true))
+	cmdr.InternalResetWorker() // flush the outputs
+	if _, ok := err.(*cmdr.ValidationError); !ok {
+		t.Fatalf("expecting a *ValidationError from ~~lint, but got %v", err)
+	}
+	cmdr.ResetOptions()
+}
+
+func TestValidateExamples(t *testing.T) {
+	defer resetOsArgs()
+
+	var invoked bool
+	root := cmdr.Root("examples-app", "1.0.0")
+	server := root.NewSubCommand("server", "s").
+		Examples(`
+$ {{.AppName}} server start --port 8080
+  starts the server on port 8080
+$ {{.AppName}} server strat
+$ {{.AppName}} s start --prot 80 | tee log.txt
+$ {{.AppName}} server start --level 'verbose'
+$ other-app server strat
+`)
+	server.NewSubCommand("start").
+		Action(func(cmd *cmdr.Command, args []string) (err error) {
+			invoked = true
+			return
+		})
+	server.NewFlagV(8080, "port", "p")
+	server.NewFlagV("info", "level").ValidArgs("debug", "info")
+
+	err := cmdr.ValidateExamples(root.RootCommand())
+	e, ok := err.(*cmdr.ValidationError)
+	if !ok {
+		t.Fatalf("expecting a *ValidationError, but got %v", err)
+	}
+	for _, s := range []string{
+		`examples-app server: example "$ examples-app server strat": unknown command "strat"`,
+		`examples-app server: example "$ examples-app s start --prot 80 | tee log.txt": unknown flag "--prot"`,
+		`examples-app server: example "$ examples-app server start --level 'verbose'": unexpected value "verbose" for --level, expecting one of [debug info]`,
+	} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expecting %q in the issues:\n%v", s, err)
+		}
+	}
+	if len(e.Issues) != 3 || invoked {
+		t.Fatalf("expecting 3 issues and no actions invoked, but got (invoked=%v):\n%v", invoked, err)
+	}
+
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+	prepareStreams()
+
+	os.Args = []string{"examples-app", "~~lint-examples"}
+	err = cmdr.Exec(root.RootCommand(), cmdr.WithNoLoadConfigFiles(true))
+	cmdr.InternalResetWorker() // flush the outputs
+	if _, ok := err.(*cmdr.ValidationError); !ok || invoked {
+		t.Fatalf("expecting a *ValidationError from ~~lint-examples, but got %v", err)
+	}
+	cmdr.ResetOptions()
+}
+
+func TestValidateExamplesKeepsTree(t *testing.T) {
+	root := cmdr.Root("examples-app", "1.0.0")
+	server := root.NewSubCommand("server", "s").
+		Examples(`
+$ {{.AppName}} server --dry --port 9000 --tls
+`)
+	server.NewFlagV(false, "dry")
+	server.NewFlagV(false, "tls").ToggleGroup("mode")
+	server.NewFlagV(true, "plain").ToggleGroup("mode")
+	server.NewFlagV(8080, "port", "p")
+
+	if err := cmdr.ValidateExamples(root.RootCommand()); err != nil {
+		t.Fatal(err)
+	}
+	for name, expect := range map[string]interface{}{"dry": false, "tls": false, "plain": true, "port": 8080} {
+		if f := cmdr.FindFlagRecursive(name, &root.RootCommand().Command); f == nil || f.DefaultValue != expect {
+			t.Fatalf("--%v: expect the default value %v unchanged, but got %v", name, expect, f.DefaultValue)
+		}
+	}
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+}
@@ -32,7 +32,13 @@ type (
 		Examples(examples string) (opt OptFlag)
 		Group(group string) (opt OptFlag)
 		Hidden(hidden bool) (opt OptFlag)
+		// Required marks the flag which must be given
+		Required(required bool) (opt OptFlag)
 		Deprecated(deprecation string) (opt OptFlag)
+		// RemovedIn hides the flag and makes it an error since the version
+		RemovedIn(version string) (opt OptFlag)
+		// ReplacedBy forwards the flag to a new one
+		ReplacedBy(flag OptFlag) (opt OptFlag)
 		// Action will be triggered once being parsed ok
 		Action(action func(cmd *Command, args []string) (err error)) (opt OptFlag)
 
@@ -43,6 +49,9 @@ type (
 		Placeholder(placeholder string) (opt OptFlag)
 		ExternalTool(envKeyName string) (opt OptFlag)
 		ValidArgs(list ...string) (opt OptFlag)
+		// Completion returns the candidates of the value for the shell
+		// completion dynamically
+		Completion(fn func(prefix string) []string) (opt OptFlag)
 		// HeadLike enables `head -n` mode.
 		// 'min', 'max' will be ignored at this version, its might be impl in the future.
 		// There's only one head-like flag in one command and its parent and children commands.
@@ -80,6 +89,8 @@ type (
 		Group(group string) (opt OptCmd)
 		Hidden(hidden bool) (opt OptCmd)
 		Deprecated(deprecation string) (opt OptCmd)
+		// RemovedIn hides the command and makes it an error since the version
+		RemovedIn(version string) (opt OptCmd)
 		// Action will be triggered after all command-line arguments parsed
 		Action(action func(cmd *Command, args []string) (err error)) (opt OptCmd)
 
@@ -94,6 +105,9 @@ type (
 		PostAction(post func(cmd *Command, args []string)) (opt OptCmd)
 
 		TailPlaceholder(placeholder string) (opt OptCmd)
+		// Completion returns the candidates of the positional arguments
+		// for the shell completion dynamically
+		Completion(fn func(prefix string) []string) (opt OptCmd)
 
 		// NewFlag create a new flag object and return it for further operations.
 		// Deprecated since v1.6.9, replace it with FlagV(defaultValue)
@@ -111,6 +111,12 @@ func (s *optCommandImpl) Deprecated(deprecation string) (opt OptCmd) {
 	return
 }
 
+func (s *optCommandImpl) RemovedIn(version string) (opt OptCmd) {
+	s.working.RemovedIn = version
+	opt = s
+	return
+}
+
 func (s *optCommandImpl) Action(action func(cmd *Command, args []string) (err error)) (opt OptCmd) {
 	s.working.Action = action
 	opt = s
@@ -138,6 +144,12 @@ func (s *optCommandImpl) TailPlaceholder(placeholder string) (opt OptCmd) {
 	return
 }
 
+func (s *optCommandImpl) Completion(fn func(prefix string) []string) (opt OptCmd) {
+	s.working.Completion = fn
+	opt = s
+	return
+}
+
 func (s *optCommandImpl) Bool() (opt OptFlag) {
 	flg := &Flag{}
 	s.working.Flags = uniAddFlg(s.working.Flags, flg)
@@ -79,12 +79,30 @@ func (s *optFlagImpl) Hidden(hidden bool) (opt OptFlag) {
 	return
 }
 
+func (s *optFlagImpl) Required(required bool) (opt OptFlag) {
+	s.working.Required = required
+	opt = s
+	return
+}
+
 func (s *optFlagImpl) Deprecated(deprecation string) (opt OptFlag) {
 	s.working.Deprecated = deprecation
 	opt = s
 	return
 }
 
+func (s *optFlagImpl) RemovedIn(version string) (opt OptFlag) {
+	s.working.RemovedIn = version
+	opt = s
+	return
+}
+
+func (s *optFlagImpl) ReplacedBy(flag OptFlag) (opt OptFlag) {
+	s.working.ReplacedBy = flag.ToFlag()
+	opt = s
+	return
+}
+
 func (s *optFlagImpl) Action(action func(cmd *Command, args []string) (err error)) (opt OptFlag) {
 	s.working.Action = action
 	opt = s
@@ -126,6 +144,12 @@ func (s *optFlagImpl) ValidArgs(list ...string) (opt OptFlag) {
 	return
 }
 
+func (s *optFlagImpl) Completion(fn func(prefix string) []string) (opt OptFlag) {
+	s.working.Completion = fn
+	opt = s
+	return
+}
+
 func (s *optFlagImpl) HeadLike(enable bool, min, max int64) (opt OptFlag) {
 	s.working.HeadLike = enable
 	s.working.Min, s.working.Max = min, max
@@ -748,6 +748,18 @@ func SaveObjAsToml(obj interface{}, filename string) (err error) {
 	return
 }
 
+// AsIni returns an ini string bytes about all options
+func AsIni() (b []byte) {
+	return internalGetWorker().rxxtOptions.AsIni()
+}
+
+// SaveAsIni to Save all config entries as an ini file, the comments of
+// the loaded ini files are kept.
+func SaveAsIni(filename string) (err error) {
+	err = ioutil.WriteFile(filename, AsIni(), 0644)
+	return
+}
+
 // GetHierarchyList returns the hierarchy data
 func GetHierarchyList() map[string]interface{} {
 	return internalGetWorker().rxxtOptions.GetHierarchyList()
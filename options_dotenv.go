@@ -0,0 +1,284 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"gopkg.in/hedzr/errors.v2"
+)
+
+// LoadEnvFile loads a dotenv file, such as `.env`, and sets the options
+// by its variables. See also Options.LoadEnvFile.
+func LoadEnvFile(file string) (err error) {
+	return internalGetWorker().rxxtOptions.LoadEnvFile(file)
+}
+
+// LoadEnvFile loads a dotenv file and sets the options by its variables.
+//
+// A variable is mapped to the option key whose env var name is the
+// same, with or without the env prefix. For example, both of
+// `CMDR_APP_SERVER_PORT=8080` and `APP_SERVER_PORT=8080` set the key
+// `app.server.port`. A variable under the options prefix, which isn't
+// a known key, is mapped by replacing '_' with '.'.
+//
+// The precedence is: the defaults, the config files, the dotenv files,
+// the real env vars, and then the command-line flags. A later dotenv
+// file overrides the earlier ones.
+func (s *Options) LoadEnvFile(file string) (err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(file); err == nil {
+		err = s.loadDotEnv(file, b)
+	}
+	if err != nil {
+		err = errors.New("error in loading env file '%s': %v", file, err)
+	}
+	return
+}
+
+// loadDotEnv merges the variables of a dotenv document, and applies
+// them to the options.
+func (s *Options) loadDotEnv(file string, b []byte) (err error) {
+	var (
+		vars  map[string]string
+		lines map[string]int
+	)
+	if vars, lines, err = dotenvUnmarshal(b, s.lookupEnv); err != nil {
+		return
+	}
+
+	s.rw.Lock()
+	if s.dotenv == nil {
+		s.dotenv, s.dotenvSources = make(map[string]string), make(map[string]ValueSource)
+	}
+	for k, v := range vars {
+		s.dotenv[k] = v
+		s.dotenvSources[k] = ValueSource{Layer: SourceDotEnv, File: file, Line: lines[k], Name: k}
+	}
+	s.rw.Unlock()
+
+	s.applyDotEnv(vars)
+	return
+}
+
+// applyDotEnv sets the options by the dotenv variables, except the ones
+// overridden by the real env vars.
+func (s *Options) applyDotEnv(vars map[string]string) {
+	for name, v := range vars {
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+		if key := s.dotenvKey(name); len(key) > 0 {
+			s.rw.RLock()
+			src := s.dotenvSources[name]
+			s.rw.RUnlock()
+
+			if oldval, modi := s.setNxFrom(key, v, &src); modi {
+				s.sfms(key, v, oldval)
+			}
+		}
+	}
+}
+
+// lookupEnv looks up a variable in the real env vars, and then in the
+// loaded dotenv files.
+func (s *Options) lookupEnv(name string) (v string, ok bool) {
+	if v, ok = os.LookupEnv(name); ok {
+		return
+	}
+	s.rw.RLock()
+	v, ok = s.dotenv[name]
+	s.rw.RUnlock()
+	return
+}
+
+// dotenvKey returns the option key of a dotenv variable, or empty
+// string if there is not a matched one, see LoadEnvFile.
+func (s *Options) dotenvKey(name string) (key string) {
+	prefix := strings.Join(internalGetWorker().envPrefixes, "_") + "_"
+
+	s.rw.RLock()
+	for k := range s.entries {
+		if ek := s.envKey(k); ek == name || ek == prefix+name {
+			key = k
+			break
+		}
+	}
+	s.rw.RUnlock()
+
+	if len(key) == 0 {
+		lower := strings.ToLower(strings.TrimPrefix(name, prefix))
+		if strings.HasPrefix(lower, strings.Join(internalGetWorker().rxxtPrefixes, "_")+"_") {
+			key = replaceAll(lower, "_", ".")
+		}
+	}
+	return
+}
+
+// dotenvUnmarshal parses a dotenv document:
+//
+//     # comment
+//     export NAME=value         # `export` is optional
+//     NAME=unquoted value       # ends at a ' #' comment, trimmed
+//     NAME='literal ${X}'       # taken as is
+//     NAME="a\tb ${X:-def} $Y"  # escapes, may span the lines
+//
+// `${X}` and `$X` are interpolated by the real env vars, the variables
+// defined before, and the result of lookup. `${X:-def}` uses def if X
+// is unset or empty, and `${X-def}` uses def if X is unset.
+//
+// The line numbers of the variables are returned in lines.
+func dotenvUnmarshal(b []byte, lookup func(name string) (string, bool)) (vars map[string]string, lines map[string]int, err error) {
+	vars, lines = make(map[string]string), make(map[string]int)
+	get := func(name string) (v string, ok bool) {
+		if v, ok = os.LookupEnv(name); !ok {
+			if v, ok = vars[name]; !ok && lookup != nil {
+				v, ok = lookup(name)
+			}
+		}
+		return
+	}
+
+	text := strings.Split(replaceAll(string(b), "\r\n", "\n"), "\n")
+	for i := 0; i < len(text); i++ {
+		lineno := i + 1
+		line := strings.TrimSpace(text[i])
+		if i == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		if strings.HasPrefix(line, "export ") || strings.HasPrefix(line, "export\t") {
+			line = strings.TrimSpace(line[len("export"):])
+		}
+
+		ix := strings.Index(line, "=")
+		if ix < 0 {
+			return nil, nil, errors.New("line %d: expect 'NAME=value' but got %q", lineno, line)
+		}
+		name := strings.TrimSpace(line[:ix])
+		if !dotenvValidName(name) {
+			return nil, nil, errors.New("line %d: invalid variable name %q", lineno, name)
+		}
+
+		raw := strings.TrimLeft(line[ix+1:], " \t")
+		var val, rest string
+		if len(raw) > 0 && (raw[0] == '"' || raw[0] == '\'') {
+			quote := raw[0]
+			end := dotenvClosingQuote(raw, quote)
+			// a quoted value may span the lines
+			for end < 0 && i+1 < len(text) {
+				i++
+				raw += "\n" + text[i]
+				end = dotenvClosingQuote(raw, quote)
+			}
+			if end < 0 {
+				return nil, nil, errors.New("line %d: unterminated quoted value of %v", lineno, name)
+			}
+			if quote == '\'' {
+				val = raw[1:end]
+			} else {
+				val = dotenvExpand(raw[1:end], true, get)
+			}
+			rest = strings.TrimSpace(raw[end+1:])
+		} else {
+			for j := 1; j < len(raw); j++ {
+				if raw[j] == '#' && (raw[j-1] == ' ' || raw[j-1] == '\t') {
+					raw = raw[:j]
+					break
+				}
+			}
+			val = dotenvExpand(strings.TrimSpace(raw), false, get)
+		}
+		if len(rest) > 0 && rest[0] != '#' {
+			return nil, nil, errors.New("line %d: unexpected %q after the quoted value of %v", lineno, rest, name)
+		}
+		vars[name], lines[name] = val, lineno
+	}
+	return
+}
+
+// dotenvValidName reports whether name is a valid variable name.
+func dotenvValidName(name string) bool {
+	for i, c := range name {
+		if !(c == '_' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || i > 0 && (c >= '0' && c <= '9' || c == '.')) {
+			return false
+		}
+	}
+	return len(name) > 0
+}
+
+// dotenvClosingQuote returns the index of the closing quote of s which
+// begins with quote, or -1.
+func dotenvClosingQuote(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '\\' && quote == '"' {
+			i++
+		} else if s[i] == quote {
+			return i
+		}
+	}
+	return -1
+}
+
+// dotenvExpand interpolates the variables in s, and unescapes `\n`,
+// `\t`, `\r`, `\"`, `\\` and `\$` if escapes is true.
+func dotenvExpand(s string, escapes bool, get func(name string) (string, bool)) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && escapes && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case '"', '\\', '$':
+				sb.WriteByte(s[i])
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(s[i])
+			}
+
+		case c == '$' && i+1 < len(s) && s[i+1] == '{':
+			end := strings.Index(s[i:], "}")
+			if end < 0 {
+				sb.WriteString(s[i:])
+				return sb.String()
+			}
+			expr := s[i+2 : i+end]
+			i += end
+			name, def, mode := expr, "", ""
+			if ix := strings.Index(expr, ":-"); ix > 0 {
+				name, def, mode = expr[:ix], expr[ix+2:], ":-"
+			} else if ix := strings.Index(expr, "-"); ix > 0 {
+				name, def, mode = expr[:ix], expr[ix+1:], "-"
+			}
+			v, ok := get(name)
+			if mode == "-" && !ok || mode == ":-" && len(v) == 0 {
+				v = def
+			}
+			sb.WriteString(v)
+
+		case c == '$' && i+1 < len(s) && (s[i+1] == '_' || s[i+1] >= 'A' && s[i+1] <= 'Z' || s[i+1] >= 'a' && s[i+1] <= 'z'):
+			j := i + 1
+			for j < len(s) && (s[j] == '_' || s[j] >= 'A' && s[j] <= 'Z' || s[j] >= 'a' && s[j] <= 'z' || s[j] >= '0' && s[j] <= '9') {
+				j++
+			}
+			v, _ := get(s[i+1 : j])
+			sb.WriteString(v)
+			i = j - 1
+
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,126 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestLoadEnvFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmdr-dotenv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Unsetenv("CMDR_TEST_REAL")
+	_ = os.Setenv("CMDR_TEST_REAL", "real")
+
+	file := path.Join(dir, ".env")
+	if err = ioutil.WriteFile(file, []byte(`# the sample
+export APP_NAME=dotenv sample # a comment
+APP_HOST = "localhost"
+APP_URL="http://${APP_HOST}:${APP_PORT:-8080}/$CMDR_TEST_REAL"
+APP_RAW='a\nb # c'
+APP_ESCAPED="a\tb\"c\$"
+APP_LINES="line 1
+line 2"
+APP_REAL=${CMDR_TEST_REAL-unset}
+APP_UNSET=${CMDR_TEST_UNSET-unset}
+OTHER_VAR=1
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmdr.ResetOptions()
+	if err = cmdr.LoadEnvFile(file); err != nil {
+		t.Fatal(err)
+	}
+	for key, expect := range map[string]string{
+		"app.name":    "dotenv sample",
+		"app.host":    "localhost",
+		"app.url":     "http://localhost:8080/real",
+		"app.raw":     `a\nb # c`,
+		"app.escaped": "a\tb\"c$",
+		"app.lines":   "line 1\nline 2",
+		"app.real":    "real",
+		"app.unset":   "unset",
+		"other.var":   "",
+	} {
+		if v := cmdr.GetString(key); v != expect {
+			t.Fatalf("%v: expect %q but got %q", key, expect, v)
+		}
+	}
+
+	for _, bad := range []string{"APP_X\n", "1X=1\n", "APP_X=\"a\n", "APP_X='a' b\n"} {
+		if err = ioutil.WriteFile(file, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err = cmdr.LoadEnvFile(file); err == nil {
+			t.Fatalf("expect an error for %q", bad)
+		}
+	}
+	cmdr.ResetOptions()
+}
+
+func TestEnvFilePrecedence(t *testing.T) {
+	defer resetOsArgs()
+
+	dir, err := ioutil.TempDir("", "cmdr-dotenv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_ = ioutil.WriteFile(path.Join(dir, ".env"), []byte("APP_SERVER_PORT=8081\nAPP_SERVER_HOST=dotenv\nAPP_SERVER_MICRO_SERVICE=on\n"), 0644)
+	_ = ioutil.WriteFile(path.Join(dir, "env-app.yml"), []byte("app:\n  env-prefix: CMDR\n"), 0644)
+	_ = ioutil.WriteFile(path.Join(dir, "prod.env"), []byte("APP_SERVER_PORT=8082\n"), 0644)
+
+	var port int
+	var host, micro string
+	buildRoot := func() *cmdr.RootCommand {
+		root := cmdr.Root("env-app", "1.0.0")
+		server := root.NewSubCommand("server", "s").Action(func(cmd *cmdr.Command, args []string) (err error) {
+			port, host, micro = cmdr.GetIntR("server.port"), cmdr.GetStringR("server.host"), cmdr.GetStringR("server.micro-service")
+			return
+		})
+		server.NewFlagV(8080, "port", "p")
+		server.NewFlagV("", "host")
+		server.NewFlagV("", "micro-service")
+		return root.RootCommand()
+	}
+
+	for _, c := range []struct {
+		args   []string
+		env    string
+		expect int
+	}{
+		{[]string{"env-app", "server"}, "", 8081},
+		{[]string{"env-app", "server", "--env-file", path.Join(dir, "prod.env")}, "", 8082},
+		{[]string{"env-app", "server", "--env-file=" + path.Join(dir, "prod.env")}, "9000", 9000},
+		{[]string{"env-app", "server", "--port", "9001"}, "9000", 9001},
+	} {
+		cmdr.ResetOptions()
+		cmdr.InternalResetWorker()
+		_, _ = prepareStreams()
+		if len(c.env) > 0 {
+			_ = os.Setenv("CMDR_APP_SERVER_PORT", c.env)
+		}
+
+		os.Args = c.args
+		err = cmdr.Exec(buildRoot(), cmdr.WithPredefinedLocations(dir+"/%s.yml"), cmdr.WithNoWatchConfigFiles(true))
+		_ = os.Unsetenv("CMDR_APP_SERVER_PORT")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port != c.expect || host != "dotenv" || micro != "on" {
+			t.Fatalf("%q: expect port %v from dotenv, but got %v, %q, %q", c.args, c.expect, port, host, micro)
+		}
+	}
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+}
@@ -664,13 +664,24 @@ func (s *Options) buildAutomaticEnv(rootCmd *RootCommand) (err error) {
 
 	// prefix := strings.Join(EnvPrefix,"_")
 	prefix := internalGetWorker().getPrefix() // strings.Join(RxxtPrefix, ".")
+
+	// the dotenv files, which are overridden by the real env vars below
+	s.rw.RLock()
+	dotenv := make(map[string]string)
+	for k, v := range s.dotenv {
+		dotenv[k] = v
+	}
+	s.rw.RUnlock()
+	s.applyDotEnv(dotenv)
+
 	for key := range s.entries {
 		ek := s.envKey(key)
 		if v, ok := os.LookupEnv(ek); ok {
+			src := &ValueSource{Layer: SourceEnv, Name: ek}
 			if strings.HasPrefix(key, prefix) {
-				s.Set(key[len(prefix)+1:], v)
+				s.setFrom(key[len(prefix)+1:], v, src)
 			} else {
-				s.Set(key, v)
+				s.setFrom(key, v, src)
 			}
 		}
 		// logrus.Printf("buildAutomaticEnv: %v", key)
@@ -680,16 +691,22 @@ func (s *Options) buildAutomaticEnv(rootCmd *RootCommand) (err error) {
 			// 	logrus.Debugf("                 : flag=%+v", flg)
 			// }
 			for _, ek := range flg.EnvVars {
-				if v, ok := os.LookupEnv(ek); ok {
+				if v, ok := s.lookupEnv(ek); ok {
+					src := &ValueSource{Layer: SourceEnv, Name: ek}
+					if _, inEnv := os.LookupEnv(ek); !inEnv {
+						s.rw.RLock()
+						*src = s.dotenvSources[ek]
+						s.rw.RUnlock()
+					}
 					// logrus.Debugf("buildAutomaticEnv: envvar %v found", ek)
 					// logrus.Debugf("                 : flag=%+v", flg)
 					if strings.HasPrefix(key, prefix) {
 						// logrus.Printf("setnx: %v <-- %v", key, v)
-						s.SetNx(key, v)
+						s.setNxFrom(key, v, src)
 						// logrus.Printf("setnx: %v", s.GetString(key))
 					} else {
 						// logrus.Printf("set: %v <-- %v", key, v)
-						s.Set(key, v)
+						s.setFrom(key, v, src)
 					}
 				}
 			}
@@ -761,6 +778,11 @@ func (s *Options) SetNx(key string, val interface{}) {
 }
 
 func (s *Options) setNx(key string, val interface{}) (oldval interface{}, modi bool) {
+	return s.setNxFrom(key, val, nil)
+}
+
+// setNxFrom is setNx with the source of the value, see GetSource.
+func (s *Options) setNxFrom(key string, val interface{}, src *ValueSource) (oldval interface{}, modi bool) {
 	defer s.rw.Unlock()
 	s.rw.Lock()
 
@@ -770,6 +792,7 @@ func (s *Options) setNx(key string, val interface{}) (oldval interface{}, modi b
 			return
 		}
 	}
+	s.recordSourceNoLock(key, src)
 
 	oldval = s.entries[key]
 	var leaf bool
@@ -918,6 +941,9 @@ func (s *Options) Reset() {
 	s.entries = nil
 	time.Sleep(100 * time.Millisecond)
 	s.entries = make(map[string]interface{})
+	s.dotenv = nil
+	s.sources, s.keyOrigins = nil, nil
+	s.origins, s.dotenvSources = nil, nil
 }
 
 func mx(pre, k string) string {
@@ -934,29 +960,29 @@ func mxIx(pre string, k interface{}) string {
 	return fmt.Sprintf("%v.%v", pre, k)
 }
 
-func (s *Options) loopMapMap(kdot string, m map[string]map[string]interface{}) (err error) {
+func (s *Options) loopMapMap(kdot string, m map[string]map[string]interface{}, src *ValueSource) (err error) {
 	for k, v := range m {
-		if err = s.loopMap(mx(kdot, k), v); err != nil {
+		if err = s.loopMap(mx(kdot, k), v, src); err != nil {
 			return
 		}
 	}
 	return
 }
 
-func (s *Options) loopMap(kdot string, m map[string]interface{}) (err error) {
+func (s *Options) loopMap(kdot string, m map[string]interface{}, src *ValueSource) (err error) {
 	for k, v := range m {
 		if vm, ok := v.(map[interface{}]interface{}); ok {
-			if err = s.loopIxMap(mx(kdot, k), vm); err != nil {
+			if err = s.loopIxMap(mx(kdot, k), vm, src); err != nil {
 				return
 			}
 		} else if vm, ok := v.(map[string]interface{}); ok {
-			if err = s.loopMap(mx(kdot, k), vm); err != nil {
+			if err = s.loopMap(mx(kdot, k), vm, src); err != nil {
 				return
 			}
 		} else {
 			// s.SetNx(mx(kdot, k), v)
 			key := mxIx(kdot, k)
-			if oldval, modi := s.setNx(key, v); modi {
+			if oldval, modi := s.setNxFrom(key, v, src); modi {
 				s.sfms(k, v, oldval)
 			}
 		}
@@ -964,10 +990,10 @@ func (s *Options) loopMap(kdot string, m map[string]interface{}) (err error) {
 	return
 }
 
-func (s *Options) loopIxMap(kdot string, m map[interface{}]interface{}) (err error) {
+func (s *Options) loopIxMap(kdot string, m map[interface{}]interface{}, src *ValueSource) (err error) {
 	for k, v := range m {
 		if vm, ok := v.(map[interface{}]interface{}); ok {
-			if err = s.loopIxMap(mxIx(kdot, k), vm); err != nil {
+			if err = s.loopIxMap(mxIx(kdot, k), vm, src); err != nil {
 				return
 			}
 			// } else if vm, ok := v.(map[string]interface{}); ok {
@@ -977,7 +1003,7 @@ func (s *Options) loopIxMap(kdot string, m map[interface{}]interface{}) (err err
 		} else {
 			// s.SetNx(mx(kdot, k), v)
 			key := mxIx(kdot, k)
-			if oldval, modi := s.setNx(key, v); modi {
+			if oldval, modi := s.setNxFrom(key, v, src); modi {
 				s.sfms(key, v, oldval)
 			}
 		}
@@ -994,7 +1020,11 @@ func (s *Options) DumpAsString() (str string) {
 	sort.Strings(k3)
 
 	for _, k := range k3 {
-		str = str + fmt.Sprintf("%-48v => %v\n", k, s.entries[k])
+		if src, ok := s.origins[k]; ok {
+			str = str + fmt.Sprintf("%-48v => %-24v # %v\n", k, s.entries[k], src)
+		} else {
+			str = str + fmt.Sprintf("%-48v => %v\n", k, s.entries[k])
+		}
 	}
 	str += "---------------------------------\n"
 
@@ -1020,3 +1050,11 @@ func (s *Options) GetHierarchyList() map[string]interface{} {
 	s.rw.RLock()
 	return s.hierarchy
 }
+
+// AsIni returns all entries as an ini document, with the comments of
+// the loaded ini files
+func (s *Options) AsIni() []byte {
+	defer s.rw.RUnlock()
+	s.rw.RLock()
+	return iniMarshal(s.entries, s.iniComments)
+}
@@ -0,0 +1,314 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/hedzr/errors.v2"
+)
+
+// iniUnmarshal parses an INI document into a hierarchy map. A section
+// `[app.server]` or a dotted key `server.port = 1` is mapped to the key
+// path `app.server` and `server.port`. The values are strings, a key
+// repeated in the same section, or a key with the suffix `[]`, makes a
+// slice.
+//
+// The comment lines (begin with `;` or `#`) are returned in comments
+// by the key path of the following section or key, the comments at the
+// end of the document are returned by the empty key path.
+func iniUnmarshal(b []byte) (m map[string]interface{}, comments map[string][]string, err error) {
+	m, comments = make(map[string]interface{}), make(map[string][]string)
+
+	var pending []string
+	var section []string
+	sec := m
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if lineno == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		switch {
+		case len(line) == 0:
+			continue
+
+		case line[0] == ';' || line[0] == '#':
+			pending = append(pending, line)
+			continue
+
+		case line[0] == '[':
+			end := strings.Index(line, "]")
+			if end < 0 {
+				return nil, nil, errors.New("ini: line %d: unterminated section header %q", lineno, line)
+			}
+			if rest := strings.TrimSpace(line[end+1:]); len(rest) > 0 && rest[0] != ';' && rest[0] != '#' {
+				return nil, nil, errors.New("ini: line %d: unexpected %q after the section header", lineno, rest)
+			}
+			if section = iniKeyPath(line[1:end]); len(section) == 0 {
+				return nil, nil, errors.New("ini: line %d: empty section name", lineno)
+			}
+			if sec, err = iniSection(m, section); err != nil {
+				return nil, nil, errors.New("ini: line %d: %v", lineno, err)
+			}
+			if len(pending) > 0 {
+				comments[strings.Join(section, ".")] = pending
+				pending = nil
+			}
+			continue
+		}
+
+		ix := strings.IndexAny(line, "=:")
+		if ix < 0 {
+			return nil, nil, errors.New("ini: line %d: expect 'key = value' but got %q", lineno, line)
+		}
+		key, multi := strings.TrimSpace(line[:ix]), false
+		if strings.HasSuffix(key, "[]") {
+			key, multi = strings.TrimSpace(strings.TrimSuffix(key, "[]")), true
+		}
+		parts := iniKeyPath(key)
+		if len(parts) == 0 {
+			return nil, nil, errors.New("ini: line %d: empty key", lineno)
+		}
+
+		var val string
+		if val, err = iniValue(strings.TrimSpace(line[ix+1:])); err != nil {
+			return nil, nil, errors.New("ini: line %d: %v", lineno, err)
+		}
+		var target map[string]interface{}
+		if target, err = iniSection(sec, parts[:len(parts)-1]); err == nil {
+			err = iniSet(target, parts[len(parts)-1], val, multi)
+		}
+		if err != nil {
+			return nil, nil, errors.New("ini: line %d: %v", lineno, err)
+		}
+		if len(pending) > 0 {
+			comments[strings.Join(append(append([]string{}, section...), parts...), ".")] = pending
+			pending = nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(pending) > 0 {
+		comments[""] = pending
+	}
+	return
+}
+
+// iniKeyPath splits a section name or a key by dot.
+func iniKeyPath(s string) (parts []string) {
+	for _, p := range strings.Split(s, ".") {
+		if p = strings.TrimSpace(p); len(p) > 0 {
+			parts = append(parts, p)
+		}
+	}
+	return
+}
+
+// iniSection returns the child map of m at path, the missing maps are
+// created.
+func iniSection(m map[string]interface{}, path []string) (sec map[string]interface{}, err error) {
+	sec = m
+	for i, p := range path {
+		switch v := sec[p].(type) {
+		case nil:
+			child := make(map[string]interface{})
+			sec[p], sec = child, child
+		case map[string]interface{}:
+			sec = v
+		default:
+			return nil, errors.New("%q is a value, not a section", strings.Join(path[:i+1], "."))
+		}
+	}
+	return
+}
+
+// iniSet sets key of a section to val, or appends val if the key exists.
+func iniSet(sec map[string]interface{}, key, val string, multi bool) (err error) {
+	switch v := sec[key].(type) {
+	case nil:
+		if multi {
+			sec[key] = []string{val}
+		} else {
+			sec[key] = val
+		}
+	case string:
+		sec[key] = []string{v, val}
+	case []string:
+		sec[key] = append(v, val)
+	default:
+		err = errors.New("%q is a section, not a value", key)
+	}
+	return
+}
+
+// iniValue parses the value part of a line. A double-quoted value is
+// unquoted as a Go string literal, a single-quoted value is taken as is,
+// and an unquoted value ends at a comment mark preceded by a space.
+func iniValue(s string) (val string, err error) {
+	var rest string
+	switch {
+	case strings.HasPrefix(s, `"`):
+		end := -1
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == '"' {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			return "", errors.New("unterminated quoted value %v", s)
+		}
+		if val, err = strconv.Unquote(s[:end+1]); err != nil {
+			return "", errors.New("bad quoted value %v: %v", s[:end+1], err)
+		}
+		rest = s[end+1:]
+
+	case strings.HasPrefix(s, "'"):
+		end := strings.Index(s[1:], "'")
+		if end < 0 {
+			return "", errors.New("unterminated quoted value %v", s)
+		}
+		val, rest = s[1:end+1], s[end+2:]
+
+	default:
+		for i := 1; i < len(s); i++ {
+			if (s[i] == ';' || s[i] == '#') && (s[i-1] == ' ' || s[i-1] == '\t') {
+				s = s[:i]
+				break
+			}
+		}
+		return strings.TrimSpace(s), nil
+	}
+
+	if rest = strings.TrimSpace(rest); len(rest) > 0 && rest[0] != ';' && rest[0] != '#' {
+		err = errors.New("unexpected %q after the quoted value", rest)
+	}
+	return
+}
+
+// iniMarshal writes the entries as an INI document. The entries are
+// grouped into the sections by the parent key path, a slice is written
+// as a repeated key, and the comments are written before the sections
+// and the keys they belong to. The keys which cannot be represented in
+// INI are skipped.
+func iniMarshal(entries map[string]interface{}, comments map[string][]string) []byte {
+	leaves := make(map[string]interface{})
+	iniFlatten(leaves, "", entries)
+
+	sections := make(map[string][]string)
+	for k := range leaves {
+		if !iniValidKey(k) {
+			continue
+		}
+		sec := ""
+		if ix := strings.LastIndex(k, "."); ix >= 0 {
+			sec = k[:ix]
+		}
+		sections[sec] = append(sections[sec], k)
+	}
+	var names []string
+	for sec := range sections {
+		names = append(names, sec)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	writeComments := func(key string) {
+		for _, c := range comments[key] {
+			buf.WriteString(c + "\n")
+		}
+	}
+	for _, sec := range names {
+		if len(sec) > 0 {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			writeComments(sec)
+			buf.WriteString("[" + sec + "]\n")
+		}
+
+		keys := sections[sec]
+		sort.Strings(keys)
+		for _, k := range keys {
+			writeComments(k)
+			name := k[strings.LastIndex(k, ".")+1:]
+			v := reflect.ValueOf(leaves[k])
+			if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+				buf.WriteString(fmt.Sprintf("%v = %v\n", name, iniQuote(iniString(leaves[k]))))
+				continue
+			}
+			if v.Len() == 1 {
+				// keep it a slice while reading back
+				name += "[]"
+			}
+			for i := 0; i < v.Len(); i++ {
+				buf.WriteString(fmt.Sprintf("%v = %v\n", name, iniQuote(iniString(v.Index(i).Interface()))))
+			}
+		}
+	}
+
+	if c, ok := comments[""]; ok {
+		buf.WriteString("\n" + strings.Join(c, "\n") + "\n")
+	}
+	return buf.Bytes()
+}
+
+// iniFlatten collects the leaf values of the map m into leaves by the
+// key paths.
+func iniFlatten(leaves map[string]interface{}, prefix string, m map[string]interface{}) {
+	for k, v := range m {
+		switch vm := v.(type) {
+		case map[string]interface{}:
+			iniFlatten(leaves, mx(prefix, k), vm)
+		case map[interface{}]interface{}:
+			sm := make(map[string]interface{})
+			for kk, vv := range vm {
+				sm[fmt.Sprintf("%v", kk)] = vv
+			}
+			iniFlatten(leaves, mx(prefix, k), sm)
+		default:
+			leaves[mx(prefix, k)] = v
+		}
+	}
+}
+
+// iniValidKey reports whether the key path can be written and read
+// back, such as "app.x." or "app.a=b" cannot.
+func iniValidKey(k string) bool {
+	for _, p := range strings.Split(k, ".") {
+		if len(p) == 0 || strings.TrimSpace(p) != p || strings.ContainsAny(p, "=:[];#") {
+			return false
+		}
+	}
+	return true
+}
+
+// iniString formats a leaf value.
+func iniString(v interface{}) string {
+	switch vv := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(vv)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// iniQuote quotes the value if it cannot be read back as is.
+func iniQuote(s string) string {
+	if len(s) == 0 || strings.TrimSpace(s) != s || s[0] == '"' || s[0] == '\'' || strings.ContainsAny(s, ";#\r\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
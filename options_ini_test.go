@@ -0,0 +1,119 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+const iniSample = `; the sample config
+name = ini sample ; an inline comment
+
+[app.server]
+# the listening port
+port = 8080
+host: 0.0.0.0
+quoted = "a ; b\tc"
+raw = 'C:\path#1'
+peers = 10.0.0.1
+peers = 10.0.0.2
+tags[] = primary
+
+[app.logger]
+level = debug
+file.path = /var/log/app.log
+
+; the end
+`
+
+func TestIniConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmdr-ini")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cmdr.ResetOptions()
+	file := path.Join(dir, "app.ini")
+	if err = ioutil.WriteFile(file, []byte(iniSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = cmdr.LoadConfigFile(file); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, expect := range map[string]string{
+		"name":                  "ini sample",
+		"app.server.host":       "0.0.0.0",
+		"app.server.quoted":     "a ; b\tc",
+		"app.server.raw":        `C:\path#1`,
+		"app.logger.level":      "debug",
+		"app.logger.file.path":  "/var/log/app.log",
+		"app.server.peers":      "[10.0.0.1 10.0.0.2]",
+		"app.server.tags":       "[primary]",
+		"app.server.not-exists": "",
+	} {
+		if v := cmdr.GetString(key); v != expect {
+			t.Fatalf("%v: expect %q but got %q", key, expect, v)
+		}
+	}
+	if v := cmdr.GetIntP("app.server", "port"); v != 8080 {
+		t.Fatalf("app.server.port: expect 8080 but got %v", v)
+	}
+	if v := cmdr.GetStringSliceP("app.server", "peers"); len(v) != 2 || v[1] != "10.0.0.2" {
+		t.Fatalf("app.server.peers: expect 2 peers but got %v", v)
+	}
+
+	saved := path.Join(dir, "saved.ini")
+	if err = cmdr.SaveAsIni(saved); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := ioutil.ReadFile(saved)
+	for _, expect := range []string{
+		"; the sample config\nname = ini sample\n",
+		"[app.server]\n",
+		"# the listening port\nport = 8080\n",
+		"peers = 10.0.0.1\npeers = 10.0.0.2\n",
+		"tags[] = primary\n",
+		`quoted = "a ; b\tc"`,
+		`raw = "C:\\path#1"`,
+		"[app.logger.file]\npath = /var/log/app.log\n",
+		"\n; the end\n",
+	} {
+		if !strings.Contains(string(b), expect) {
+			t.Fatalf("expect %q in the saved ini file, but got:\n%v", expect, string(b))
+		}
+	}
+
+	// read it back
+	cmdr.ResetOptions()
+	if err = cmdr.LoadConfigFile(saved); err != nil {
+		t.Fatal(err)
+	}
+	if v := cmdr.GetStringSliceP("app.server", "tags"); len(v) != 1 || v[0] != "primary" {
+		t.Fatalf("app.server.tags: expect [primary] but got %v", v)
+	}
+	if v := cmdr.GetString("app.server.raw"); v != `C:\path#1` {
+		t.Fatalf("app.server.raw: expect %q but got %q", `C:\path#1`, v)
+	}
+
+	for _, bad := range []string{
+		"[app.server\nport = 1\n",
+		"[app]\nport\n",
+		"[app]\nport = \"1\n",
+		"[app]\nport = 1\n[app.port]\nx = 1\n",
+	} {
+		if err = ioutil.WriteFile(file, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err = cmdr.LoadConfigFile(file); err == nil {
+			t.Fatalf("expect an error for %q", bad)
+		}
+	}
+}
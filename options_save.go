@@ -0,0 +1,262 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"gopkg.in/hedzr/errors.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// configSource is a loaded yaml config file, its nodes are kept so that
+// the changes can be written back with the comments and the layout,
+// see SaveConfigChanges.
+type configSource struct {
+	file   string
+	doc    *yaml.Node
+	indent int
+	// leaves are the value nodes by the key paths
+	leaves map[string]*yaml.Node
+	// values are the values of the leaves when loaded or saved
+	values map[string]interface{}
+}
+
+// SaveConfigChanges writes the changed options back into the config
+// files they came from, the main config file or the files in `conf.d`.
+// The comments and the layout of the files are kept, and the files
+// without changes are not touched. The values from the command-line
+// flags, the env vars and the dotenv files are one-off overrides, they
+// are not saved.
+//
+// The newKeys which are not from any config file are added into the
+// main config file. Like Set, the keys MUST not have an `app` prefix:
+//
+//     cmdr.Set("server.port", 9000)
+//     cmdr.Set("server.tls.enabled", true)
+//     err := cmdr.SaveConfigChanges("server.tls.enabled")
+//
+// Only the yaml config files are supported.
+func SaveConfigChanges(newKeys ...string) (err error) {
+	var keys []string
+	for _, k := range newKeys {
+		keys = append(keys, wrapWithRxxtPrefix(k))
+	}
+	return internalGetWorker().rxxtOptions.SaveConfigChanges(keys...)
+}
+
+// SaveConfigChanges writes the changed options back into the config
+// files they came from, and adds the newKeys into the main config file.
+// The keys are the full key paths, such as "app.server.port".
+//
+// See also SaveConfigChanges.
+func (s *Options) SaveConfigChanges(newKeys ...string) (err error) {
+	defer s.rw.Unlock()
+	s.rw.Lock()
+
+	dirty := make(map[*configSource]bool)
+	for key, file := range s.keyOrigins {
+		cs := s.sources[file]
+		val, ok := s.entries[key]
+		if !ok || fmt.Sprint(val) == fmt.Sprint(cs.values[key]) || s.overriddenNoLock(key) {
+			continue
+		}
+		if err = yamlSetValue(cs.leaves[key], val); err != nil {
+			return errors.New("cannot update '%v' in '%v': %v", key, file, err)
+		}
+		cs.values[key] = val
+		dirty[cs] = true
+	}
+
+	for _, key := range newKeys {
+		val, ok := s.entries[key]
+		if _, exists := s.keyOrigins[key]; exists || !ok {
+			continue
+		}
+		cs := s.sources[s.usedConfigFile]
+		if cs == nil {
+			return errors.New("cannot add '%v': the main config file '%v' is not a loaded yaml file", key, s.usedConfigFile)
+		}
+		if err = cs.insert(key, val); err != nil {
+			return errors.New("cannot add '%v' into '%v': %v", key, cs.file, err)
+		}
+		s.keyOrigins[key] = cs.file
+		dirty[cs] = true
+	}
+
+	for cs := range dirty {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(cs.indent)
+		if err = enc.Encode(cs.doc); err == nil {
+			err = enc.Close()
+		}
+		if err == nil {
+			err = ioutil.WriteFile(cs.file, buf.Bytes(), 0644)
+		}
+		if err != nil {
+			return errors.New("cannot save '%v': %v", cs.file, err)
+		}
+	}
+	return
+}
+
+// overriddenNoLock reports whether the value of key came from a flag,
+// an env var or a dotenv file.
+func (s *Options) overriddenNoLock(key string) bool {
+	switch s.origins[key].Layer {
+	case SourceFlag, SourceEnv, SourceDotEnv:
+		return true
+	}
+	return false
+}
+
+// trackConfigFile keeps the nodes of a loaded yaml config file, and
+// records it as the origin of its keys.
+func (s *Options) trackConfigFile(file string, b []byte) (err error) {
+	switch path.Ext(file) {
+	case ".yml", ".yaml":
+	default:
+		return
+	}
+
+	cs := &configSource{
+		file:   file,
+		doc:    new(yaml.Node),
+		leaves: make(map[string]*yaml.Node),
+		values: make(map[string]interface{}),
+	}
+	if err = yaml.Unmarshal(b, cs.doc); err != nil {
+		return
+	}
+	if len(cs.doc.Content) > 0 {
+		cs.walk("", cs.doc.Content[0])
+	}
+	if cs.indent <= 0 {
+		cs.indent = 2
+	}
+
+	s.rw.Lock()
+	defer s.rw.Unlock()
+	if s.sources == nil {
+		s.sources, s.keyOrigins = make(map[string]*configSource), make(map[string]string)
+	}
+	s.sources[file] = cs
+	for key, leaf := range cs.leaves {
+		s.keyOrigins[key] = file
+		if o, ok := s.origins[key]; ok && o.Layer == SourceConfig && o.File == file {
+			o.Line = leaf.Line
+			s.origins[key] = o
+		}
+	}
+	return
+}
+
+// walk collects the leaves of the mapping node n, and detects the
+// indent of the file.
+func (cs *configSource) walk(prefix string, n *yaml.Node) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		key := mx(prefix, k.Value)
+		switch v.Kind {
+		case yaml.MappingNode:
+			if cs.indent == 0 && len(v.Content) > 0 {
+				cs.indent = v.Content[0].Column - k.Column
+			}
+			cs.walk(key, v)
+		case yaml.AliasNode:
+			// an alias cannot be updated without breaking the anchor
+		default:
+			var val interface{}
+			if v.Decode(&val) == nil {
+				cs.leaves[key], cs.values[key] = v, val
+			}
+		}
+	}
+}
+
+// insert adds the key path and its value into the document, the
+// missing mappings are created.
+func (cs *configSource) insert(key string, val interface{}) (err error) {
+	if len(cs.doc.Content) == 0 {
+		cs.doc.Kind = yaml.DocumentNode
+		cs.doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	n := cs.doc.Content[0]
+	parts := strings.Split(key, ".")
+	for i, p := range parts {
+		if n.Kind != yaml.MappingNode {
+			return errors.New("'%v' is not a mapping", strings.Join(parts[:i], "."))
+		}
+		var child *yaml.Node
+		for j := 0; j+1 < len(n.Content); j += 2 {
+			if n.Content[j].Value == p {
+				child = n.Content[j+1]
+				break
+			}
+		}
+
+		if i == len(parts)-1 {
+			if child != nil {
+				return errors.New("'%v' exists already", key)
+			}
+			if child, err = yamlValueNode(val); err == nil {
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: p}, child)
+				cs.leaves[key], cs.values[key] = child, val
+			}
+			return
+		}
+
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: p}, child)
+		}
+		n = child
+	}
+	return
+}
+
+// yamlSetValue replaces the value of a node, and keeps its comments.
+func yamlSetValue(node *yaml.Node, val interface{}) (err error) {
+	// keep the type of a scalar such as `port: 8080`, while the new
+	// value is a string from the command line or the env vars
+	if str, ok := val.(string); ok && node.Kind == yaml.ScalarNode && node.ShortTag() != "!!str" {
+		n := yaml.Node{Kind: yaml.ScalarNode, Tag: node.ShortTag(), Value: str}
+		var x interface{}
+		if n.Decode(&x) == nil {
+			node.Value = str
+			return
+		}
+	}
+
+	var n *yaml.Node
+	if n, err = yamlValueNode(val); err == nil {
+		n.HeadComment, n.LineComment, n.FootComment = node.HeadComment, node.LineComment, node.FootComment
+		n.Line, n.Column = node.Line, node.Column
+		*node = *n
+	}
+	return
+}
+
+// yamlValueNode returns the node of a value.
+func yamlValueNode(val interface{}) (n *yaml.Node, err error) {
+	var b []byte
+	defer handleSerializeError(&err)
+	if b, err = yaml.Marshal(val); err != nil {
+		return
+	}
+	var doc yaml.Node
+	if err = yaml.Unmarshal(b, &doc); err == nil && len(doc.Content) > 0 {
+		n = doc.Content[0]
+	} else if err == nil {
+		n = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+	return
+}
@@ -0,0 +1,142 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestSaveConfigChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmdr-save")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	mainFile, extra, other := path.Join(dir, "app.yml"), path.Join(dir, "conf.d", "extra.yml"), path.Join(dir, "conf.d", "other.yml")
+	_ = os.MkdirAll(path.Join(dir, "conf.d"), 0755)
+	_ = ioutil.WriteFile(mainFile, []byte(`# the main config
+app:
+    server:
+        # the listening port
+        port: 8080 # http
+        host: localhost
+    logger:
+        level: info
+`), 0644)
+	_ = ioutil.WriteFile(extra, []byte(`app:
+    logger:
+        # overridden here
+        level: warn
+`), 0644)
+	otherContent := "app:\n  # untouched\n  name:   other\n"
+	_ = ioutil.WriteFile(other, []byte(otherContent), 0644)
+
+	cmdr.ResetOptions()
+	cmdr.Set("no-watch-conf-dir", true)
+	if err = cmdr.LoadConfigFile(mainFile); err != nil {
+		t.Fatal(err)
+	}
+	if v := cmdr.GetStringR("logger.level"); v != "warn" {
+		t.Fatalf("logger.level: expect warn from conf.d but got %q", v)
+	}
+
+	cmdr.Set("server.port", "9000") // a string from the command line
+	cmdr.Set("logger.level", "debug")
+	cmdr.Set("server.tls.enabled", true)
+	cmdr.Set("server.tags", []string{"a", "b"})
+	if err = cmdr.SaveConfigChanges("server.tls.enabled", "server.tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := ioutil.ReadFile(mainFile)
+	for _, expect := range []string{
+		"# the main config\n",
+		"        # the listening port\n        port: 9000 # http\n",
+		"        host: localhost\n",
+		"    logger:\n        level: info\n",
+		"        tls:\n            enabled: true\n",
+		"        tags:\n",
+		"- a\n",
+	} {
+		if !strings.Contains(string(b), expect) {
+			t.Fatalf("expect %q in the main config file, but got:\n%v", expect, string(b))
+		}
+	}
+	b, _ = ioutil.ReadFile(extra)
+	if expect := "        # overridden here\n        level: debug\n"; !strings.Contains(string(b), expect) {
+		t.Fatalf("expect %q in conf.d/extra.yml, but got:\n%v", expect, string(b))
+	}
+	if b, _ = ioutil.ReadFile(other); string(b) != otherContent {
+		t.Fatalf("conf.d/other.yml should not be touched, but got:\n%v", string(b))
+	}
+
+	// read them back
+	cmdr.ResetOptions()
+	cmdr.Set("no-watch-conf-dir", true)
+	if err = cmdr.LoadConfigFile(mainFile); err != nil {
+		t.Fatal(err)
+	}
+	if v := cmdr.GetIntR("server.port"); v != 9000 {
+		t.Fatalf("server.port: expect 9000 but got %v", v)
+	}
+	if v := cmdr.GetStringR("logger.level"); v != "debug" {
+		t.Fatalf("logger.level: expect debug but got %q", v)
+	}
+	if !cmdr.GetBoolR("server.tls.enabled") {
+		t.Fatal("server.tls.enabled: expect true")
+	}
+	cmdr.ResetOptions()
+}
+
+func TestSaveConfigChangesSkipsOverrides(t *testing.T) {
+	defer resetOsArgs()
+
+	dir, err := ioutil.TempDir("", "cmdr-save")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	mainFile := path.Join(dir, "save-app.yml")
+	_ = ioutil.WriteFile(mainFile, []byte(`app:
+  server:
+    port: 8080 # http
+    host: localhost
+    level: info
+`), 0644)
+	_ = os.Setenv("SAVE_APP_HOST", "remote")
+	defer os.Unsetenv("SAVE_APP_HOST")
+
+	root := cmdr.Root("save-app", "1.0.0")
+	server := root.NewSubCommand("server", "s").Action(func(cmd *cmdr.Command, args []string) (err error) {
+		cmdr.Set("server.level", "debug")
+		return cmdr.SaveConfigChanges()
+	})
+	server.NewFlagV(8080, "port", "p")
+	server.NewFlagV("", "host").EnvKeys("SAVE_APP_HOST")
+	server.NewFlagV("", "level")
+
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+	_, _ = prepareStreams()
+	os.Args = []string{"save-app", "server", "--port", "9001"}
+	if err = cmdr.Exec(root.RootCommand(), cmdr.WithPredefinedLocations(dir+"/%s.yml"), cmdr.WithNoWatchConfigFiles(true)); err != nil {
+		t.Fatal(err)
+	}
+	cmdr.InternalResetWorker()
+
+	b, _ := ioutil.ReadFile(mainFile)
+	for _, expect := range []string{"port: 8080 # http\n", "host: localhost\n", "level: debug\n"} {
+		if !strings.Contains(string(b), expect) {
+			t.Fatalf("expect %q in the config file, but got:\n%v", expect, string(b))
+		}
+	}
+	cmdr.ResetOptions()
+}
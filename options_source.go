@@ -0,0 +1,91 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValueSource tells where the value of an option key came from, see
+// GetSource.
+type ValueSource struct {
+	// Layer is one of SourceDefault, SourceConfig, SourceDotEnv,
+	// SourceEnv, SourceFlag and SourceCode
+	Layer string
+	// File is the config file or the dotenv file
+	File string
+	// Line is the line number in File, or 0 if unknown
+	Line int
+	// Name is the env var or the flag
+	Name string
+}
+
+// The layers of ValueSource, from the lowest precedence to the highest
+const (
+	// SourceDefault is the default value of a flag
+	SourceDefault = "default"
+	// SourceConfig is a config file, the main one or one in `conf.d`
+	SourceConfig = "config"
+	// SourceDotEnv is a dotenv file
+	SourceDotEnv = "dotenv"
+	// SourceEnv is an env var
+	SourceEnv = "env"
+	// SourceFlag is a command-line flag
+	SourceFlag = "flag"
+	// SourceCode is set by the code, such as cmdr.Set
+	SourceCode = "code"
+)
+
+// String returns the source in one line, such as:
+//
+//     config /etc/app/app.yml:12
+//     dotenv .env:3 APP_SERVER_PORT
+//     env CMDR_APP_SERVER_PORT
+//     flag --port
+func (vs ValueSource) String() string {
+	parts := []string{vs.Layer}
+	if len(vs.File) > 0 {
+		if vs.Line > 0 {
+			parts = append(parts, fmt.Sprintf("%v:%v", vs.File, vs.Line))
+		} else {
+			parts = append(parts, vs.File)
+		}
+	}
+	if len(vs.Name) > 0 {
+		parts = append(parts, vs.Name)
+	}
+	return strings.Join(parts, " ")
+}
+
+// GetSource returns where the value of an option key came from, for
+// debugging. The key is the full key path, such as "app.server.port".
+func GetSource(key string) (src ValueSource, ok bool) {
+	return internalGetWorker().rxxtOptions.GetSource(key)
+}
+
+// GetSource returns where the value of an option key came from.
+func (s *Options) GetSource(key string) (src ValueSource, ok bool) {
+	defer s.rw.RUnlock()
+	s.rw.RLock()
+	src, ok = s.origins[key]
+	return
+}
+
+// setFrom is Set with the source of the value.
+func (s *Options) setFrom(key string, val interface{}, src *ValueSource) {
+	s.setNxFrom(wrapWithRxxtPrefix(key), val, src)
+}
+
+// recordSourceNoLock records src as the source of key, a nil src
+// means SourceCode.
+func (s *Options) recordSourceNoLock(key string, src *ValueSource) {
+	if s.origins == nil {
+		s.origins = make(map[string]ValueSource)
+	}
+	if src != nil {
+		s.origins[key] = *src
+	} else {
+		s.origins[key] = ValueSource{Layer: SourceCode}
+	}
+}
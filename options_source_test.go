@@ -0,0 +1,90 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestGetSource(t *testing.T) {
+	defer resetOsArgs()
+
+	dir, err := ioutil.TempDir("", "cmdr-source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_ = os.MkdirAll(path.Join(dir, "conf.d"), 0755)
+	_ = ioutil.WriteFile(path.Join(dir, "src-app.yml"), []byte("app:\n  server:\n    port: 8081\n    host: main\n  env-prefix: CMDR\n"), 0644)
+	_ = ioutil.WriteFile(path.Join(dir, "conf.d", "host.yml"), []byte("# the host\napp:\n  server:\n    host: conf.d\n"), 0644)
+	_ = ioutil.WriteFile(path.Join(dir, ".env"), []byte("# the level\nAPP_SERVER_LEVEL=warn\n"), 0644)
+	_ = os.Setenv("CMDR_APP_SERVER_NAMESPACE", "prod")
+	defer os.Unsetenv("CMDR_APP_SERVER_NAMESPACE")
+
+	root := cmdr.Root("src-app", "1.0.0")
+	server := root.NewSubCommand("server", "s").Action(func(cmd *cmdr.Command, args []string) (err error) { return })
+	server.NewFlagV(8080, "port", "p")
+	server.NewFlagV("", "host")
+	server.NewFlagV("info", "level")
+	server.NewFlagV("default", "namespace", "n")
+	server.NewFlagV("", "work-dir", "w")
+	server.NewFlagV(false, "dry-run")
+
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+	_, _ = prepareStreams()
+	os.Args = []string{"src-app", "server", "-w", "/tmp"}
+	if err = cmdr.Exec(root.RootCommand(), cmdr.WithPredefinedLocations(dir+"/%s.yml"), cmdr.WithNoWatchConfigFiles(true)); err != nil {
+		t.Fatal(err)
+	}
+	cmdr.Set("server.dry-run", true)
+
+	for key, expect := range map[string]cmdr.ValueSource{
+		"app.server.port":      {Layer: cmdr.SourceConfig, File: path.Join(dir, "src-app.yml"), Line: 3},
+		"app.server.host":      {Layer: cmdr.SourceConfig, File: path.Join(dir, "conf.d", "host.yml"), Line: 4},
+		"app.server.level":     {Layer: cmdr.SourceDotEnv, File: path.Join(dir, ".env"), Line: 2, Name: "APP_SERVER_LEVEL"},
+		"app.server.namespace": {Layer: cmdr.SourceEnv, Name: "CMDR_APP_SERVER_NAMESPACE"},
+		"app.server.work-dir":  {Layer: cmdr.SourceFlag, Name: "-w"},
+		"app.server.dry-run":   {Layer: cmdr.SourceCode},
+		"app.verbose":          {Layer: cmdr.SourceDefault},
+	} {
+		if src, ok := cmdr.GetSource(key); !ok || src != expect {
+			t.Fatalf("%v: expect the source %q but got %q (%v)", key, expect, src, ok)
+		}
+	}
+	if src, _ := cmdr.GetSource("app.server.port"); src.String() != "config "+path.Join(dir, "src-app.yml")+":3" {
+		t.Fatalf("bad string of the source: %q", src.String())
+	}
+	if _, ok := cmdr.GetSource("app.not-exists"); ok {
+		t.Fatal("expect no source for a key not exists")
+	}
+
+	// the values set while loading a dotenv file concurrently
+	_ = ioutil.WriteFile(path.Join(dir, "extra.env"), []byte("APP_SERVER_EXTRA=x\n"), 0644)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = cmdr.LoadEnvFile(path.Join(dir, "extra.env"))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		cmdr.Set("server.tag", i)
+		if src, _ := cmdr.GetSource("app.server.tag"); src.Layer != cmdr.SourceCode {
+			t.Fatalf("expect the source of a value set by code is %q but got %q", cmdr.SourceCode, src)
+		}
+	}
+	<-done
+	if src, _ := cmdr.GetSource("app.server.extra"); src.Layer != cmdr.SourceDotEnv {
+		t.Fatalf("expect the source %q but got %q", cmdr.SourceDotEnv, src)
+	}
+
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+}
@@ -155,14 +155,18 @@ func (s *Options) loadConfigFile(file string) (err error) {
 	)
 
 	b, _ = ioutil.ReadFile(file)
+	src := &ValueSource{Layer: SourceConfig, File: file}
 
 	m = make(map[string]interface{})
 	switch path.Ext(file) {
-	case ".toml", ".ini", ".conf", "toml":
+	case ".ini", "ini":
+		return s.loadIni(b, src)
+
+	case ".toml", ".conf", "toml":
 		mm = make(map[string]map[string]interface{})
 		err = toml.Unmarshal(b, &mm)
 		if err == nil {
-			err = s.loopMapMap("", mm)
+			err = s.loopMapMap("", mm, src)
 		}
 		if err != nil {
 			return
@@ -176,27 +180,32 @@ func (s *Options) loadConfigFile(file string) (err error) {
 	}
 
 	if err == nil {
-		err = s.loopMap("", m)
+		err = s.loopMap("", m, src)
 	}
-	if err != nil {
-		return
+	if err == nil {
+		err = s.trackConfigFile(file, b)
 	}
-
 	return
 }
 
-func (s *Options) mergeConfigFile(fr io.Reader, ext string) (err error) {
+func (s *Options) mergeConfigFile(fr io.Reader, file string) (err error) {
 	var (
 		m   map[string]interface{}
 		buf *bytes.Buffer
+		ext = path.Ext(file)
 	)
+	src := &ValueSource{Layer: SourceConfig, File: file}
 
 	buf = new(bytes.Buffer)
 	_, err = buf.ReadFrom(fr)
 
 	m = make(map[string]interface{})
 	switch ext {
-	case ".toml", ".ini", ".conf", "toml":
+	case ".ini", "ini":
+		return s.loadIni(buf.Bytes(), src)
+	case ".env", "env":
+		return s.loadDotEnv(file, buf.Bytes())
+	case ".toml", ".conf", "toml":
 		err = toml.Unmarshal(buf.Bytes(), &m)
 	case ".json", "json":
 		err = json.Unmarshal(buf.Bytes(), &m)
@@ -205,7 +214,7 @@ func (s *Options) mergeConfigFile(fr io.Reader, ext string) (err error) {
 	}
 
 	if err == nil {
-		err = s.loopMap("", m)
+		err = s.loopMap("", m, src)
 	}
 	if err != nil {
 		return
@@ -214,13 +223,36 @@ func (s *Options) mergeConfigFile(fr io.Reader, ext string) (err error) {
 	return
 }
 
+// loadIni merges the settings of an ini document into `Options`, and
+// keeps its comments for SaveAsIni.
+func (s *Options) loadIni(b []byte, src *ValueSource) (err error) {
+	var (
+		m        map[string]interface{}
+		comments map[string][]string
+	)
+	if m, comments, err = iniUnmarshal(b); err != nil {
+		return
+	}
+
+	s.rw.Lock()
+	if s.iniComments == nil {
+		s.iniComments = make(map[string][]string)
+	}
+	for k, v := range comments {
+		s.iniComments[k] = v
+	}
+	s.rw.Unlock()
+
+	return s.loopMap("", m, src)
+}
+
 func (s *Options) visit(path string, f os.FileInfo, e error) (err error) {
 	// fmt.Printf("Visited: %s, e: %v\n", path, e)
 	if f != nil && !f.IsDir() && e == nil {
 		// log.Infof("    path: %v, ext: %v", path, filepath.Ext(path))
 		ext := filepath.Ext(path)
 		switch ext {
-		case ".yml", ".yaml", ".json", ".toml", ".ini", ".conf": // , "yml", "yaml":
+		case ".yml", ".yaml", ".json", ".toml", ".ini", ".conf", ".env": // , "yml", "yaml":
 			var file *os.File
 			file, err = os.Open(path)
 			// if err != nil {
@@ -228,10 +260,13 @@ func (s *Options) visit(path string, f os.FileInfo, e error) (err error) {
 			// } else {
 			if err == nil {
 				defer file.Close()
-				if err = s.mergeConfigFile(bufio.NewReader(file), ext); err != nil {
+				if err = s.mergeConfigFile(bufio.NewReader(file), path); err != nil {
 					err = errors.New("error in merging config file '%s': %v", path, err)
 					return
 				}
+				if b, e := ioutil.ReadFile(path); e == nil {
+					_ = s.trackConfigFile(path, b)
+				}
 				s.configFiles = uniAddStr(s.configFiles, path)
 			} else {
 				err = errors.New("error in merging config file '%s': %v", path, err)
@@ -270,7 +305,7 @@ func (s *Options) watchConfigDir(configDir string, filesWatching []string) {
 }
 
 func testCfgSuffix(name string) bool {
-	for _, suf := range []string{".yaml", ".yml", ".json", ".toml", ".ini", ".conf"} {
+	for _, suf := range []string{".yaml", ".yml", ".json", ".toml", ".ini", ".conf", ".env"} {
 		if strings.HasSuffix(name, suf) {
 			return true
 		}
@@ -0,0 +1,94 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultPager is used if the env var PAGER is empty
+const defaultPager = "less -R"
+
+// Paged runs fn and pipes its output through the pager, which is
+// `$PAGER` or `less -R`, when the stdout is a terminal and the output
+// is taller than the terminal. Otherwise the output is written to the
+// stdout as is.
+//
+// The help screen, `--tree`, `~~debug` and `--build-info` are paged in
+// this way. An Action can page its long output too:
+//
+//     err = cmdr.Paged(func(out io.Writer) (err error) {
+//         for _, line := range lines {
+//             _, err = fmt.Fprintln(out, line)
+//         }
+//         return
+//     })
+//
+// The pager can be disabled by `--no-pager`, the env var NOPAGER or
+// WithNoPager(true).
+func Paged(fn func(out io.Writer) error) (err error) {
+	w := internalGetWorker()
+	root := w.rootCommand
+	if root == nil || root.ow == nil {
+		return fn(os.Stdout)
+	}
+	if w.paging {
+		// the outer Paged() collects the output
+		return fn(root.ow)
+	}
+
+	var buf bytes.Buffer
+	saved := root.ow
+	root.ow, w.paging = bufio.NewWriter(&buf), true
+	defer func() {
+		_ = root.ow.Flush()
+		root.ow, w.paging = saved, false
+		w.page(buf.Bytes())
+	}()
+
+	err = fn(root.ow)
+	return
+}
+
+// page writes data to the stdout, or to the pager if necessary.
+func (w *ExecWorker) page(data []byte) {
+	out := w.rootCommand.ow
+	if w.shouldPage(data) {
+		_ = out.Flush()
+		if err := runPager(data); err == nil {
+			return
+		}
+	}
+	_, _ = out.Write(data)
+}
+
+func (w *ExecWorker) shouldPage(data []byte) bool {
+	if w.noPager || w.customStdout || GetBoolR("no-pager") || !isTerminal(os.Stdout) {
+		return false
+	}
+	_, height := terminalSize(os.Stdout)
+	return height > 0 && bytes.Count(data, []byte("\n")) >= height-1
+}
+
+// runPager returns an error only if the pager cannot be started, so
+// that the caller can fall back to the stdout.
+func runPager(data []byte) (err error) {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = strings.Fields(defaultPager)
+	}
+
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = cmd.Start(); err == nil {
+		_ = cmd.Wait()
+	}
+	return
+}
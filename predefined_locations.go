@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/hedzr/cmdr/conf"
 	"os"
+	"path"
 	"strings"
 )
 
@@ -41,14 +42,7 @@ func (w *ExecWorker) parsePredefinedLocation() (err error) {
 func (w *ExecWorker) loadFromPredefinedLocation(rootCmd *RootCommand) (err error) {
 	// and now, loading the external configuration files
 	for _, s := range w.getExpandedPredefinedLocations() {
-		fn := s
-		switch strings.Count(fn, "%s") {
-		case 2:
-			fn = fmt.Sprintf(s, rootCmd.AppName, rootCmd.AppName)
-		case 1:
-			fn = fmt.Sprintf(s, rootCmd.AppName)
-		}
-
+		fn := expandLocation(s, rootCmd.AppName)
 		b := FileExists(fn)
 		if !b {
 			fn = replaceAll(fn, ".yml", ".yaml")
@@ -65,6 +59,46 @@ func (w *ExecWorker) loadFromPredefinedLocation(rootCmd *RootCommand) (err error
 	return
 }
 
+// loadEnvFiles loads the first `.env` found in the directories of the
+// predefined locations, and then the file specified by `--env-file`.
+func (w *ExecWorker) loadEnvFiles(rootCmd *RootCommand) (err error) {
+	for _, s := range w.getExpandedPredefinedLocations() {
+		fn := path.Join(path.Dir(expandLocation(s, rootCmd.AppName)), ".env")
+		if FileExists(fn) {
+			if err = w.rxxtOptions.LoadEnvFile(fn); err != nil {
+				return
+			}
+			break
+		}
+	}
+
+	// pre-detects for `--env-file xxx`, `--env-file=xxx`
+	if ix, str, yes := partialContains(os.Args, "--env-file"); yes {
+		var file string
+		if i := strings.Index(str, "="); i > 0 {
+			file = str[i+1:]
+		} else if ix+1 < len(os.Args) {
+			file = os.Args[ix+1]
+		}
+
+		if len(file) > 0 {
+			err = w.rxxtOptions.LoadEnvFile(trimQuotes(file))
+		}
+	}
+	return
+}
+
+// expandLocation fills the app name into a predefined location.
+func expandLocation(s, appName string) string {
+	switch strings.Count(s, "%s") {
+	case 2:
+		return fmt.Sprintf(s, appName, appName)
+	case 1:
+		return fmt.Sprintf(s, appName)
+	}
+	return s
+}
+
 // getExpandedPredefinedLocations for internal using
 func (w *ExecWorker) getExpandedPredefinedLocations() (locations []string) {
 	for _, d := range internalGetWorker().predefinedLocations {
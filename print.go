@@ -7,6 +7,7 @@ package cmdr
 import (
 	"fmt"
 	"github.com/hedzr/cmdr/conf"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -23,19 +24,22 @@ func ferr(fmtStr string, args ...interface{}) {
 func (w *ExecWorker) printHelp(command *Command, justFlags bool) {
 	initTabStop(defaultTabStop)
 
-	if GetIntR("help-zsh") > 0 {
-		w.printHelpZsh(command, justFlags)
-	} else if GetBoolR("help-bash") {
-		// TODO for bash
-		w.printHelpZsh(command, justFlags)
-	} else {
-		w.paintFromCommand(w.currentHelpPainter, command, justFlags)
-	}
+	_ = Paged(func(out io.Writer) (err error) {
+		if GetIntR("help-zsh") > 0 {
+			w.printHelpZsh(command, justFlags)
+		} else if GetBoolR("help-bash") {
+			// TODO for bash
+			w.printHelpZsh(command, justFlags)
+		} else {
+			w.paintFromCommand(w.currentHelpPainter, command, justFlags)
+		}
 
-	// NOTE: checking `~~debug`
-	if w.rxxtOptions.GetBoolEx("debug", false) {
-		w.paintTildeDebugCommand()
-	}
+		// NOTE: checking `~~debug`
+		if w.rxxtOptions.GetBoolEx("debug", false) {
+			w.paintTildeDebugCommand()
+		}
+		return
+	})
 	if w.currentHelpPainter != nil {
 		w.currentHelpPainter.Results()
 		w.currentHelpPainter.Reset()
@@ -105,7 +109,7 @@ func (w *ExecWorker) printHelpZshCommands(command *Command, justFlags bool) {
 		x.WriteString(fmt.Sprintf("%d: :((", GetIntP(w.getPrefix(), "help-zsh")))
 		for _, cx := range command.SubCommands {
 			for _, n := range cx.GetExpandableNamesArray() {
-				x.WriteString(fmt.Sprintf(`%v:'%v' `, n, cx.Description))
+				x.WriteString(fmt.Sprintf(`%v:'%v' `, n, cx.GetDescription()))
 			}
 
 			// fp(`  %-25s  %v%v`, cx.GetName(), cx.GetQuotedGroupName(), cx.Description)
@@ -134,14 +138,14 @@ func (w *ExecWorker) printHelpZshCommands(command *Command, justFlags bool) {
 
 func (w *ExecWorker) printHelpUsages(p Painter, command *Command) {
 	if len(w.rootCommand.Header) == 0 || !command.IsRoot() {
-		p.FpUsagesTitle(command, "Usages")
+		p.FpUsagesTitle(command, T("Usages"))
 
-		ttl := "[Commands] "
+		ttl := T("[Commands] ")
 		if command.owner != nil {
 			if len(command.SubCommands) == 0 {
 				ttl = ""
 			} else {
-				ttl = "[Sub-Commands] "
+				ttl = T("[Sub-Commands] ")
 			}
 		}
 
@@ -156,7 +160,7 @@ func (w *ExecWorker) printHelpUsages(p Painter, command *Command) {
 
 func (w *ExecWorker) printHelpDescription(p Painter, command *Command) {
 	if len(command.Description) > 0 {
-		p.FpDescTitle(command, "Description")
+		p.FpDescTitle(command, T("Description"))
 		p.FpDescLine(command)
 		// fp("\nDescription: \n    %v", command.Description)
 	}
@@ -164,7 +168,7 @@ func (w *ExecWorker) printHelpDescription(p Painter, command *Command) {
 
 func (w *ExecWorker) printHelpExamples(p Painter, command *Command) {
 	if len(command.Examples) > 0 {
-		p.FpExamplesTitle(command, "Examples")
+		p.FpExamplesTitle(command, T("Examples"))
 		p.FpExamplesLine(command)
 		// fp("%v", command.Examples)
 	}
@@ -242,8 +246,71 @@ func getSortedKeysFromFlgMap(groups map[string]*Flag) (k3 []string) {
 	return
 }
 
+func flagDefaultValueString(flg *Flag) (defValStr string) {
+	if flg.DefaultValue != nil {
+		if ss, ok := flg.DefaultValue.(string); ok && len(ss) > 0 {
+			if len(flg.DefaultValuePlaceholder) > 0 {
+				defValStr = fmt.Sprintf(" (default %v='%s')", flg.DefaultValuePlaceholder, ss)
+			} else {
+				defValStr = fmt.Sprintf(" (default='%s')", ss)
+			}
+		} else {
+			if len(flg.DefaultValuePlaceholder) > 0 {
+				defValStr = fmt.Sprintf(" (default %v=%v)", flg.DefaultValuePlaceholder, flg.DefaultValue)
+			} else {
+				defValStr = fmt.Sprintf(" (default=%v)", flg.DefaultValue)
+			}
+		}
+	}
+	return
+}
+
+// flagBinding tells where the value of a flag could come from and
+// where the effective value came from.
+type flagBinding struct {
+	envVars []string    // the bound env vars: Flag.EnvVars and the automatic one
+	key     string      // the config key path, such as 'app.server.port'
+	value   interface{} // the effective value
+	source  ValueSource // the source of value, see GetSource
+}
+
+// flagBindingsEnabled returns true if the bindings of flags should be
+// painted, see WithHelpFlagBindings and `--help-bindings`.
+func flagBindingsEnabled() bool {
+	return internalGetWorker().helpFlagBindings || GetBoolR("help-bindings")
+}
+
+func getFlagBinding(flg *Flag) (b *flagBinding) {
+	w := internalGetWorker()
+	b = &flagBinding{key: wrapWithRxxtPrefix(w.backtraceFlagNames(flg)), source: ValueSource{Layer: SourceDefault}}
+
+	for _, ek := range flg.EnvVars {
+		if len(ek) > 0 {
+			b.envVars = uniAddStr(b.envVars, ek)
+		}
+	}
+	if w.rxxtOptions == nil {
+		b.value = flg.DefaultValue
+		return
+	}
+	b.envVars = uniAddStr(b.envVars, w.rxxtOptions.envKey(b.key))
+	b.value = w.rxxtOptions.Get(b.key)
+	if src, ok := w.rxxtOptions.GetSource(b.key); ok {
+		b.source = src
+	}
+	return
+}
+
+// String returns the bindings in one line, such as:
+//
+//     env: APP_PORT, CMDR_APP_SERVER_PORT; config: app.server.port; value: 8080 (default)
+func (b *flagBinding) String() string {
+	return fmt.Sprintf("%v: %v; %v: %v; %v: %v (%v)",
+		T("env"), strings.Join(b.envVars, ", "), T("config"), b.key, T("value"), b.value, b.source)
+}
+
 func printHelpFlagSections(p Painter, command *Command, justFlags bool) {
-	sectionName := "Options"
+	sectionName := T("Options")
 
 GO_PRINT_FLAGS:
 	count := 0
@@ -261,23 +328,7 @@ GO_PRINT_FLAGS:
 				for _, nm := range getSortedKeysFromFlgMap(groups) {
 					flg := groups[nm]
 					if !flg.Hidden {
-						defValStr := ""
-						if flg.DefaultValue != nil {
-							if ss, ok := flg.DefaultValue.(string); ok && len(ss) > 0 {
-								if len(flg.DefaultValuePlaceholder) > 0 {
-									defValStr = fmt.Sprintf(" (default %v='%s')", flg.DefaultValuePlaceholder, ss)
-								} else {
-									defValStr = fmt.Sprintf(" (default='%s')", ss)
-								}
-							} else {
-								if len(flg.DefaultValuePlaceholder) > 0 {
-									defValStr = fmt.Sprintf(" (default %v=%v)", flg.DefaultValuePlaceholder, flg.DefaultValue)
-								} else {
-									defValStr = fmt.Sprintf(" (default=%v)", flg.DefaultValue)
-								}
-							}
-						}
-						p.FpFlagsLine(command, flg, defValStr)
+						p.FpFlagsLine(command, flg, flagDefaultValueString(flg))
 						// fp("  %-48s%v%s", flg.GetTitleFlagNames(), flg.Description, defValStr)
 					}
 				}
@@ -289,9 +340,9 @@ GO_PRINT_FLAGS:
 		command = command.owner
 		// sectionName = "Parent/Global Options"
 		if command.owner == nil {
-			sectionName = "Global Options"
+			sectionName = T("Global Options")
 		} else {
-			sectionName = fmt.Sprintf("Parent (`%v`) Options", command.GetTitleName())
+			sectionName = T("Parent (`%v`) Options", command.GetTitleName())
 		}
 		goto GO_PRINT_FLAGS
 	}
@@ -317,10 +368,13 @@ func (w *ExecWorker) showBuildInfo() {
 		return
 	}
 
-	w.printHeader(w.currentHelpPainter, &w.rootCommand.Command)
-	// buildTime
-	fp(`
+	_ = Paged(func(out io.Writer) (err error) {
+		w.printHeader(w.currentHelpPainter, &w.rootCommand.Command)
+		// buildTime
+		fp(`
        Built by: %v
 Build Timestamp: %v
         Githash: %v`, conf.GoVersion, conf.Buildstamp, conf.Githash)
+		return
+	})
 }
@@ -4,7 +4,14 @@
 
 package cmdr
 
-import "time"
+import (
+	"fmt"
+	"gopkg.in/hedzr/errors.v2"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // some refs:
 // - github.com/labstack/gommon/color
@@ -67,3 +74,179 @@ const (
 	// DarkColor terminal color code
 	DarkColor = FgLightGray
 )
+
+// Color is a terminal color code. Besides the 16 colors above (FgRed,
+// FgLightBlue, ...), a color can be one of the 256-color palette by
+// Color256, or a 24-bit color by RGB.
+//
+// A Color formats itself as the SGR parameters by '%d', so it can be
+// used in "\x1b[%dm" directly:
+//
+//     fmt.Printf("\x1b[%dm%s\x1b[0m", cmdr.RGB(255, 128, 0), "orange")
+//
+// The 24-bit and 256 colors will be degraded by the color capability
+// of the terminal, see COLORTERM and TERM.
+//
+// The int vars such as CurrentDescColor hold the basic ANSI colors
+// only, see ApplyTheme.
+type Color int
+
+const (
+	color256Flag Color = 1 << 24
+	colorRGBFlag Color = 1 << 25
+)
+
+// Color256 returns the color n (0..255) of the 256-color palette.
+func Color256(n uint8) Color {
+	return color256Flag | Color(n)
+}
+
+// RGB returns a 24-bit true color.
+func RGB(r, g, b uint8) Color {
+	return colorRGBFlag | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// Format implements fmt.Formatter, it prints the SGR parameters of the
+// foreground color for '%d', '%v' and '%s'.
+func (c Color) Format(f fmt.State, verb rune) {
+	_, _ = fmt.Fprint(f, c.sgr(colorLevel()))
+}
+
+// sgr returns the SGR parameters of c, degraded to the color level:
+// 0 for 16 colors, 1 for 256 colors and 2 for 24-bit colors.
+func (c Color) sgr(level int) string {
+	switch {
+	case c&colorRGBFlag != 0:
+		r, g, b := int(c>>16&0xff), int(c>>8&0xff), int(c&0xff)
+		if level >= 2 {
+			return fmt.Sprintf("38;2;%d;%d;%d", r, g, b)
+		}
+		return Color256(rgbTo256(r, g, b)).sgr(level)
+	case c&color256Flag != 0:
+		n := int(c & 0xff)
+		if level >= 1 {
+			return fmt.Sprintf("38;5;%d", n)
+		}
+		return strconv.Itoa(int(c256ToBasic(n)))
+	}
+	return strconv.Itoa(int(c))
+}
+
+// basic returns the nearest one of the 16 basic colors.
+func (c Color) basic() int {
+	n, _ := strconv.Atoi(c.sgr(0))
+	return n
+}
+
+// colorLevel detects the color capability of the terminal.
+func colorLevel() int {
+	if ct := strings.ToLower(os.Getenv("COLORTERM")); ct == "truecolor" || ct == "24bit" {
+		return 2
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return 1
+	}
+	return 0
+}
+
+// rgbTo256 maps a 24-bit color to the 6x6x6 color cube or the
+// grayscale ramp of the 256-color palette.
+func rgbTo256(r, g, b int) uint8 {
+	if r == g && g == b {
+		switch {
+		case r < 8:
+			return 16
+		case r > 248:
+			return 231
+		}
+		return uint8(232 + (r-8)*24/247)
+	}
+	cube := func(v int) int {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	return uint8(16 + 36*cube(r) + 6*cube(g) + cube(b))
+}
+
+// c256ToBasic maps a color of the 256-color palette to the nearest
+// one of the 16 basic colors.
+func c256ToBasic(n int) Color {
+	switch {
+	case n < 8:
+		return Color(FgBlack + n)
+	case n < 16:
+		return Color(FgDarkGray + n - 8)
+	case n >= 232:
+		switch {
+		case n < 238:
+			return FgBlack
+		case n < 244:
+			return FgDarkGray
+		case n < 250:
+			return FgLightGray
+		}
+		return FgWhite
+	}
+	n -= 16
+	r, g, b := n/36, n/6%6, n%6
+	code := 0
+	if r >= 3 {
+		code |= 1
+	}
+	if g >= 3 {
+		code |= 2
+	}
+	if b >= 3 {
+		code |= 4
+	}
+	if r >= 4 || g >= 4 || b >= 4 {
+		return Color(FgDarkGray + code)
+	}
+	return Color(FgBlack + code)
+}
+
+var colorNames = map[string]Color{
+	"black":         FgBlack,
+	"red":           FgRed,
+	"green":         FgGreen,
+	"yellow":        FgYellow,
+	"blue":          FgBlue,
+	"magenta":       FgMagenta,
+	"cyan":          FgCyan,
+	"light-gray":    FgLightGray,
+	"dark-gray":     FgDarkGray,
+	"light-red":     FgLightRed,
+	"light-green":   FgLightGreen,
+	"light-yellow":  FgLightYellow,
+	"light-blue":    FgLightBlue,
+	"light-magenta": FgLightMagenta,
+	"light-cyan":    FgLightCyan,
+	"white":         FgWhite,
+}
+
+// ParseColor parses a color name such as "dark-gray" or "light-blue",
+// a number of the 256-color palette such as "208", or a 24-bit color
+// such as "#ff8800".
+func ParseColor(s string) (c Color, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if v, ok := colorNames[replaceAll(s, "_", "-")]; ok {
+		return v, nil
+	}
+	if strings.HasPrefix(s, "#") && len(s) == 7 {
+		var v uint64
+		if v, err = strconv.ParseUint(s[1:], 16, 32); err == nil {
+			c = RGB(uint8(v>>16), uint8(v>>8), uint8(v))
+			return
+		}
+	} else if n, e := strconv.ParseUint(s, 10, 8); e == nil {
+		c = Color256(uint8(n))
+		return
+	}
+	err = errors.New("unknown color %q", s)
+	return
+}
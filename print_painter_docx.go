@@ -0,0 +1,360 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type (
+	// docxPainter paints a command as the body fragment of a WordprocessingML
+	// document, all fragments will be packaged into one .docx file by
+	// writeDocx().
+	docxPainter struct {
+		writer io.Writer
+		// pendingTitle and pendingGroup will be printed lazily while
+		// the first row of a table coming.
+		pendingTitle string
+		pendingGroup string
+		inTable      bool
+	}
+)
+
+func newDocxPainter() *docxPainter {
+	return &docxPainter{
+		writer: new(bytes.Buffer),
+	}
+}
+
+func (s *docxPainter) Results() (res []byte) {
+	if bb, ok := s.writer.(*bytes.Buffer); ok {
+		res = bb.Bytes()
+	}
+	return
+}
+
+func (s *docxPainter) Reset() {
+	s.writer = new(bytes.Buffer)
+	s.pendingTitle, s.pendingGroup, s.inTable = "", "", false
+}
+
+func (s *docxPainter) Flush() {
+}
+
+func (s *docxPainter) Printf(fmtStr string, args ...interface{}) {
+	_, _ = fmt.Fprintf(s.writer, fmtStr, args...)
+}
+
+// para prints a paragraph with the style, the lines of text are
+// separated by the line breaks.
+func (s *docxPainter) para(style, text string) {
+	s.Printf("%v", docxPara(style, text))
+}
+
+// code prints each line of text as a paragraph in monospace.
+func (s *docxPainter) code(text string) {
+	for _, line := range strings.Split(strings.Trim(text, "\n"), "\n") {
+		s.para("Code", strings.TrimRight(line, " \t"))
+	}
+}
+
+func (s *docxPainter) closeTable() {
+	if s.inTable {
+		s.Printf("</w:tbl>\n")
+		s.inTable = false
+	}
+}
+
+func (s *docxPainter) openTable(headers ...string) {
+	if len(s.pendingTitle) > 0 {
+		s.para("SectionTitle", s.pendingTitle)
+		s.pendingTitle = ""
+	}
+	if len(s.pendingGroup) > 0 {
+		s.para("GroupTitle", s.pendingGroup)
+		s.pendingGroup = ""
+	}
+	if !s.inTable {
+		s.Printf(`<w:tbl><w:tblPr><w:tblStyle w:val="CmdrTable"/><w:tblW w:w="5000" w:type="pct"/></w:tblPr><w:tblGrid><w:gridCol w:w="3000"/><w:gridCol w:w="1800"/><w:gridCol w:w="4600"/></w:tblGrid>`)
+		s.row(true, headers...)
+		s.inTable = true
+	}
+}
+
+func (s *docxPainter) row(header bool, cells ...string) {
+	s.Printf("<w:tr>")
+	if header {
+		s.Printf(`<w:trPr><w:tblHeader/></w:trPr>`)
+	}
+	for i, c := range cells {
+		style := "TableText"
+		if header {
+			style = "TableHeader"
+		} else if i == 0 {
+			style = "TableCode"
+		}
+		s.Printf("<w:tc>%v</w:tc>", docxPara(style, c))
+	}
+	s.Printf("</w:tr>\n")
+}
+
+func (s *docxPainter) FpPrintHeader(command *Command) {
+	if command.IsRoot() {
+		root := command.root
+		s.para("Title", fmt.Sprintf("%v v%v", root.AppName, root.Version))
+		if len(root.Copyright) > 0 || len(root.Author) > 0 {
+			s.para("Subtitle", strings.TrimSpace(root.Copyright+" by "+root.Author))
+		}
+		return
+	}
+
+	level := findDepth(command) - 1
+	if level > 4 {
+		level = 4
+	}
+	s.para(fmt.Sprintf("Heading%d", level), replaceAll(internalGetWorker().backtraceCmdNames(command), ".", " "))
+	if len(command.Deprecated) > 0 {
+		s.para("Deprecated", T("deprecated since %v", command.Deprecated))
+	}
+	if len(command.Short) > 0 || len(command.Aliases) > 0 {
+		s.para("Normal", T("Names: %v", command.GetTitleNames()))
+	}
+}
+
+func (s *docxPainter) FpPrintHelpTailLine(command *Command) {
+	s.closeTable()
+}
+
+func (s *docxPainter) FpUsagesTitle(command *Command, title string) {
+	s.para("SectionTitle", title)
+}
+
+func (s *docxPainter) FpUsagesLine(command *Command, fmt, appName, cmdList, cmdsTitle, tailPlaceHolder string) {
+	if len(tailPlaceHolder) == 0 {
+		tailPlaceHolder = T("[tail args...]")
+	}
+	s.code(strings.Join(strings.Fields(appName+" "+cmdList+cmdsTitle+tailPlaceHolder+" "+T("[Options] [Parent/Global Options]")+fmt), " "))
+}
+
+func (s *docxPainter) FpDescTitle(command *Command, title string) {
+	s.para("SectionTitle", title)
+}
+
+func (s *docxPainter) FpDescLine(command *Command) {
+	desc := command.GetLongDescription()
+	if len(desc) == 0 {
+		desc = command.GetDescription()
+	}
+	for _, p := range strings.Split(strings.TrimSpace(desc), "\n\n") {
+		if p = strings.TrimSpace(p); len(p) > 0 {
+			s.para("Normal", p)
+		}
+	}
+}
+
+func (s *docxPainter) FpExamplesTitle(command *Command, title string) {
+	s.para("SectionTitle", title)
+}
+
+func (s *docxPainter) FpExamplesLine(command *Command) {
+	s.code(tplApply(command.GetExamples(), command.root))
+}
+
+func (s *docxPainter) FpCommandsTitle(command *Command) {
+	s.closeTable()
+	s.pendingTitle = T("Sub-Commands")
+}
+
+func (s *docxPainter) FpCommandsGroupTitle(group string) {
+	s.closeTable()
+	s.pendingGroup = ""
+	if group != UnsortedGroup {
+		s.pendingGroup = StripOrderPrefix(group)
+	}
+}
+
+func (s *docxPainter) FpCommandsLine(command *Command) {
+	if command.Hidden {
+		return
+	}
+
+	var aliases []string
+	if len(command.Short) > 0 && len(command.Full) > 0 {
+		aliases = append(aliases, command.Short)
+	}
+	aliases = append(aliases, command.Aliases...)
+
+	desc := command.GetDescription()
+	if len(command.Deprecated) > 0 {
+		desc += " (" + T("deprecated since %v", command.Deprecated) + ")"
+	}
+
+	s.openTable(T("Command"), T("Aliases"), T("Description"))
+	s.row(false, command.GetTitleName(), strings.Join(aliases, ", "), desc)
+}
+
+func (s *docxPainter) FpFlagsTitle(command *Command, flag *Flag, title string) {
+	s.closeTable()
+	s.pendingTitle = title
+}
+
+func (s *docxPainter) FpFlagsGroupTitle(group string) {
+	s.closeTable()
+	s.pendingGroup = ""
+	if group != UnsortedGroup {
+		s.pendingGroup = StripOrderPrefix(group)
+	}
+}
+
+func (s *docxPainter) FpFlagsLine(command *Command, flag *Flag, defValStr string) {
+	var titles []string
+	if len(flag.Short) > 0 {
+		titles = append(titles, "-"+flag.Short)
+	}
+	if len(flag.Full) > 0 {
+		title := "--" + flag.Full
+		if len(flag.DefaultValuePlaceholder) > 0 {
+			title += "=" + flag.DefaultValuePlaceholder
+		}
+		titles = append(titles, title)
+	}
+	for _, a := range flag.Aliases {
+		titles = append(titles, "--"+a)
+	}
+
+	var defVal string
+	if flag.DefaultValue != nil {
+		defVal = fmt.Sprint(flag.DefaultValue)
+	}
+
+	desc := flag.GetDescription()
+	if len(flag.Deprecated) > 0 {
+		desc += " (" + T("deprecated since %v", flag.Deprecated) + ")"
+	}
+	if len(flag.LongDescription) > 0 {
+		desc += "\n" + strings.TrimSpace(flag.GetLongDescription())
+	}
+	if len(flag.ValidArgs) > 0 {
+		desc += "\n" + T("One of: %v", strings.Join(flag.ValidArgs, ", "))
+	}
+	if len(flag.Examples) > 0 {
+		desc += "\n" + strings.Trim(tplApply(flag.GetExamples(), command.root), "\n")
+	}
+
+	s.openTable(T("Flag"), T("Default"), T("Description"))
+	s.row(false, strings.Join(titles, ", "), defVal, desc)
+}
+
+// docxPara returns a paragraph with the style, the lines of text are
+// separated by the line breaks.
+func docxPara(style, text string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<w:p><w:pPr><w:pStyle w:val="%v"/></w:pPr>`, style))
+	for i, line := range strings.Split(text, "\n") {
+		sb.WriteString("<w:r>")
+		if i > 0 {
+			sb.WriteString("<w:br/>")
+		}
+		sb.WriteString(`<w:t xml:space="preserve">`)
+		sb.WriteString(docxEscape(line))
+		sb.WriteString("</w:t></w:r>")
+	}
+	sb.WriteString("</w:p>\n")
+	return sb.String()
+}
+
+func docxEscape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// writeDocx packages the painted body as an Office Open XML document.
+func writeDocx(w io.Writer, title string, body []byte) (err error) {
+	zw := zip.NewWriter(w)
+	parts := []struct {
+		name, content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRels},
+		{"docProps/core.xml", fmt.Sprintf(docxCore, docxEscape(title), time.Now().UTC().Format(time.RFC3339))},
+		{"word/_rels/document.xml.rels", docxDocumentRels},
+		{"word/styles.xml", docxStyles},
+		{"word/document.xml", fmt.Sprintf(docxDocument, string(body))},
+	}
+	for _, part := range parts {
+		var f io.Writer
+		if f, err = zw.Create(part.name); err != nil {
+			return
+		}
+		if _, err = io.WriteString(f, part.content); err != nil {
+			return
+		}
+	}
+	err = zw.Close()
+	return
+}
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`
+
+const docxDocumentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+const docxCore = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+<dc:title>%v</dc:title>
+<dc:creator>hedzr/cmdr</dc:creator>
+<dcterms:created xsi:type="dcterms:W3CDTF">%v</dcterms:created>
+</cp:coreProperties>`
+
+const docxDocument = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+%v<w:p/>
+<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1440" w:right="1440" w:bottom="1440" w:left="1440" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr>
+</w:body>
+</w:document>`
+
+const docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults>
+<w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:eastAsia="Calibri" w:cs="Calibri"/><w:sz w:val="21"/></w:rPr></w:rPrDefault>
+<w:pPrDefault><w:pPr><w:spacing w:after="120"/></w:pPr></w:pPrDefault>
+</w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>
+<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:spacing w:after="240"/></w:pPr><w:rPr><w:sz w:val="52"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:rPr><w:i/><w:color w:val="595959"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="360" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="32"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="240" w:after="120"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:sz w:val="28"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading3"><w:name w:val="heading 3"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="240" w:after="120"/><w:outlineLvl w:val="2"/></w:pPr><w:rPr><w:b/><w:sz w:val="24"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading4"><w:name w:val="heading 4"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="240" w:after="120"/><w:outlineLvl w:val="3"/></w:pPr><w:rPr><w:b/><w:i/></w:rPr></w:style>
+<w:style w:type="paragraph" w:customStyle="1" w:styleId="SectionTitle"><w:name w:val="Section Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="160" w:after="60"/></w:pPr><w:rPr><w:b/></w:rPr></w:style>
+<w:style w:type="paragraph" w:customStyle="1" w:styleId="GroupTitle"><w:name w:val="Group Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:after="60"/></w:pPr><w:rPr><w:i/></w:rPr></w:style>
+<w:style w:type="paragraph" w:customStyle="1" w:styleId="Deprecated"><w:name w:val="Deprecated"/><w:basedOn w:val="Normal"/><w:rPr><w:i/><w:color w:val="C00000"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:customStyle="1" w:styleId="Code"><w:name w:val="Code"/><w:basedOn w:val="Normal"/><w:pPr><w:shd w:val="clear" w:color="auto" w:fill="F2F2F2"/><w:spacing w:after="0"/></w:pPr><w:rPr><w:rFonts w:ascii="Consolas" w:hAnsi="Consolas" w:cs="Courier New"/><w:sz w:val="18"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:customStyle="1" w:styleId="TableText"><w:name w:val="Table Text"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:after="0"/></w:pPr></w:style>
+<w:style w:type="paragraph" w:customStyle="1" w:styleId="TableHeader"><w:name w:val="Table Header"/><w:basedOn w:val="TableText"/><w:rPr><w:b/></w:rPr></w:style>
+<w:style w:type="paragraph" w:customStyle="1" w:styleId="TableCode"><w:name w:val="Table Code"/><w:basedOn w:val="TableText"/><w:rPr><w:rFonts w:ascii="Consolas" w:hAnsi="Consolas" w:cs="Courier New"/><w:sz w:val="18"/></w:rPr></w:style>
+<w:style w:type="table" w:customStyle="1" w:styleId="CmdrTable"><w:name w:val="Cmdr Table"/><w:tblPr><w:tblBorders><w:top w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/><w:left w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/><w:bottom w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/><w:right w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/><w:insideH w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/></w:tblBorders><w:tblCellMar><w:left w:w="80" w:type="dxa"/><w:right w:w="80" w:type="dxa"/></w:tblCellMar></w:tblPr></w:style>
+</w:styles>`
@@ -0,0 +1,65 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestWriteDocx(t *testing.T) {
+	p := newDocxPainter()
+	p.FpUsagesTitle(nil, "Usages")
+	p.FpUsagesLine(nil, "", "app", "server ", "", "")
+	p.FpFlagsTitle(nil, nil, "Options")
+	p.FpFlagsGroupTitle(UnsortedGroup)
+	p.FpFlagsLine(nil, &Flag{BaseOpt: BaseOpt{Short: "p", Full: "port", Description: "<port> & host"}, DefaultValue: 8080}, "")
+	p.FpPrintHelpTailLine(nil)
+
+	var buf bytes.Buffer
+	if err := writeDocx(&buf, "app & co", p.Results()); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		var rc io.ReadCloser
+		if rc, err = f.Open(); err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(rc)
+		_ = rc.Close()
+		parts[f.Name] = string(b)
+
+		// each part must be a well-formed xml
+		d := xml.NewDecoder(bytes.NewReader(b))
+		for {
+			if _, err = d.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%v: %v", f.Name, err)
+			}
+		}
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/styles.xml", "word/_rels/document.xml.rels"} {
+		if _, ok := parts[name]; !ok {
+			t.Fatalf("part %q not found", name)
+		}
+	}
+	doc := parts["word/document.xml"]
+	for _, s := range []string{"<w:tbl>", "--port", "&lt;port&gt; &amp; host", `<w:pStyle w:val="Code"/>`} {
+		if !strings.Contains(doc, s) {
+			t.Fatalf("expecting %q in document.xml:\n%v", s, doc)
+		}
+	}
+}
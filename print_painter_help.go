@@ -40,9 +40,9 @@ func (s *helpPainter) FpPrintHeader(command *Command) {
 func (s *helpPainter) FpPrintHelpTailLine(command *Command) {
 	if internalGetWorker().enableHelpCommands {
 		if GetNoColorMode() {
-			s.Printf(fmtTailLineNC, internalGetWorker().helpTailLine)
+			s.Printf(fmtTailLineNC, T(internalGetWorker().helpTailLine))
 		} else {
-			s.Printf(fmtTailLine, CurrentGroupTitleColor, internalGetWorker().helpTailLine)
+			s.Printf(fmtTailLine, groupTitleColor(), T(internalGetWorker().helpTailLine))
 		}
 	}
 }
@@ -62,9 +62,9 @@ func (s *helpPainter) FpUsagesLine(command *Command, fmt, appName, cmdList, cmds
 	if len(tailPlaceHolder) > 0 {
 		tailPlaceHolder = command.TailPlaceHolder
 	} else {
-		tailPlaceHolder = "[tail args...]"
+		tailPlaceHolder = T("[tail args...]")
 	}
-	s.Printf("    %s%v%s%s [Options] [Parent/Global Options]"+fmt, appName, cmdList, cmdsTitle, tailPlaceHolder)
+	s.Printf("    %s%v%s%s %v"+fmt, appName, cmdList, cmdsTitle, tailPlaceHolder, T("[Options] [Parent/Global Options]"))
 }
 
 func (s *helpPainter) FpDescTitle(command *Command, title string) {
@@ -72,7 +72,7 @@ func (s *helpPainter) FpDescTitle(command *Command, title string) {
 }
 
 func (s *helpPainter) FpDescLine(command *Command) {
-	s.Printf("    %v", command.Description)
+	s.Printf("    %v", command.GetDescription())
 }
 
 func (s *helpPainter) FpExamplesTitle(command *Command, title string) {
@@ -80,7 +80,7 @@ func (s *helpPainter) FpExamplesTitle(command *Command, title string) {
 }
 
 func (s *helpPainter) FpExamplesLine(command *Command) {
-	str := tplApply(command.Examples, command.root)
+	str := tplApply(command.GetExamples(), command.root)
 	for _, line := range strings.Split(str, "\n") {
 		s.Printf("    %v", line)
 	}
@@ -89,9 +89,9 @@ func (s *helpPainter) FpExamplesLine(command *Command) {
 func (s *helpPainter) FpCommandsTitle(command *Command) {
 	var title string
 	if command.owner == nil {
-		title = "Commands"
+		title = T("Commands")
 	} else {
-		title = "Sub-Commands"
+		title = T("Sub-Commands")
 	}
 	s.Printf("\n%s:", title)
 }
@@ -99,9 +99,9 @@ func (s *helpPainter) FpCommandsTitle(command *Command) {
 func (s *helpPainter) FpCommandsGroupTitle(group string) {
 	if group != UnsortedGroup {
 		if GetNoColorMode() {
-			s.Printf(fmtCmdGroupTitleNC, StripOrderPrefix(group))
+			s.Printf(fmtCmdGroupTitleNC, T(StripOrderPrefix(group)))
 		} else {
-			s.Printf(fmtCmdGroupTitle, CurrentGroupTitleColor, StripOrderPrefix(group))
+			s.Printf(fmtCmdGroupTitle, groupTitleColor(), T(StripOrderPrefix(group)))
 		}
 	}
 }
@@ -110,18 +110,18 @@ func (s *helpPainter) FpCommandsLine(command *Command) {
 	if !command.Hidden {
 		if len(command.Deprecated) > 0 {
 			if GetNoColorMode() {
-				s.Printf(fmtCmdlineDepNC, command.GetTitleNames(), command.Description, command.Deprecated)
+				s.Printf(fmtCmdlineDepNC, command.GetTitleNames(), command.GetDescription(), command.Deprecated)
 			} else {
-				s.Printf(fmtCmdlineDep, BgNormal, CurrentDescColor, command.GetTitleNames(), command.Description, command.Deprecated)
+				s.Printf(fmtCmdlineDep, BgNormal, descColor(), command.GetTitleNames(), command.GetDescription(), command.Deprecated)
 			}
 		} else {
 			if GetNoColorMode() {
-				s.Printf(fmtCmdlineNC, command.GetTitleNames(), command.Description)
+				s.Printf(fmtCmdlineNC, command.GetTitleNames(), command.GetDescription())
 			} else {
 				// s.Printf("  %-48s%v", command.GetTitleNames(), command.Description)
 				// s.Printf("\n\x1b[%dm\x1b[%dm%s\x1b[0m", BgNormal, DarkColor, title)
 				// s.Printf("  [\x1b[%dm\x1b[%dm%s\x1b[0m]", BgDim, DarkColor, StripOrderPrefix(group))
-				s.Printf(fmtCmdline, command.GetTitleNames(), BgNormal, CurrentDescColor, command.Description)
+				s.Printf(fmtCmdline, command.GetTitleNames(), BgNormal, descColor(), command.GetDescription())
 			}
 		}
 	}
@@ -144,13 +144,13 @@ func (s *helpPainter) FpFlagsTitle(command *Command, flag *Flag, title string) {
 func (s *helpPainter) FpFlagsGroupTitle(group string) {
 	if group != UnsortedGroup {
 		if GetNoColorMode() {
-			s.Printf(fmtGroupTitleNC, StripOrderPrefix(group))
+			s.Printf(fmtGroupTitleNC, T(StripOrderPrefix(group)))
 		} else {
 			// fp("  [%s]:", StripOrderPrefix(group))
 			// // echo -e "Normal \e[2mDim"
 			// _, _ = fmt.Fprintf(b, "\x1b[%dm%s\x1b[0m\x1b[2m\x1b[%dm[%04d]\x1b[0m%-48s \x1b[2m\x1b[%dm%s\x1b[0m ",
 			// 	levelColor, levelText, DarkColor, int(entry.Time.Sub(baseTimestamp)/time.Second), entry.Message, DarkColor, caller)
-			s.Printf(fmtGroupTitle, CurrentGroupTitleColor, StripOrderPrefix(group))
+			s.Printf(fmtGroupTitle, groupTitleColor(), T(StripOrderPrefix(group)))
 		}
 	}
 }
@@ -172,19 +172,28 @@ func (s *helpPainter) FpFlagsLine(command *Command, flg *Flag, defValStr string)
 	if len(flg.Deprecated) > 0 {
 		if GetNoColorMode() {
 			s.Printf(fmtFlagsDepNC, // "  %-48s%s%s [deprecated since %v]",
-				flg.GetTitleFlagNames(), flg.Description, envKeys, defValStr, flg.Deprecated)
+				flg.GetTitleFlagNames(), flg.GetDescription(), envKeys, defValStr, flg.Deprecated)
 		} else {
 			s.Printf(fmtFlagsDep, // "  \x1b[%dm\x1b[%dm%-48s%s\x1b[%dm\x1b[%dm%s\x1b[0m [deprecated since %v]",
-				BgNormal, CurrentDescColor, flg.GetTitleFlagNames(), flg.Description,
-				BgItalic, CurrentDefaultValueColor, envKeys, defValStr, flg.Deprecated)
+				BgNormal, descColor(), flg.GetTitleFlagNames(), flg.GetDescription(),
+				BgItalic, defaultValueColor(), envKeys, defValStr, flg.Deprecated)
 		}
 	} else {
 		if GetNoColorMode() {
-			s.Printf(fmtFlagsNC, flg.GetTitleFlagNames(), flg.Description, envKeys, defValStr)
+			s.Printf(fmtFlagsNC, flg.GetTitleFlagNames(), flg.GetDescription(), envKeys, defValStr)
 		} else {
 			s.Printf(fmtFlags, // "  %-48s\x1b[%dm\x1b[%dm%s\x1b[%dm\x1b[%dm%s\x1b[0m",
-				flg.GetTitleFlagNames(), BgNormal, CurrentDescColor, flg.Description,
-				BgItalic, CurrentDefaultValueColor, envKeys, defValStr)
+				flg.GetTitleFlagNames(), BgNormal, descColor(), flg.GetDescription(),
+				BgItalic, defaultValueColor(), envKeys, defValStr)
+		}
+	}
+
+	if flagBindingsEnabled() {
+		indent := strings.Repeat(" ", defaultTabStop+2)
+		if GetNoColorMode() {
+			s.Printf("%s%v", indent, getFlagBinding(flg))
+		} else {
+			s.Printf("%s\x1b[%dm\x1b[%dm%v\x1b[0m", indent, BgDim, defaultValueColor(), getFlagBinding(flg))
 		}
 	}
 }
@@ -0,0 +1,358 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+type (
+	// htmlPainter paints a command as a html fragment, the whole page
+	// (or the single page for all commands) will be assembled by
+	// document().
+	htmlPainter struct {
+		writer     io.Writer
+		singlePage bool
+		inTable    bool
+		current    *Command
+	}
+)
+
+func newHTMLPainter(singlePage bool) *htmlPainter {
+	return &htmlPainter{
+		writer:     new(bytes.Buffer),
+		singlePage: singlePage,
+	}
+}
+
+func (s *htmlPainter) Results() (res []byte) {
+	if bb, ok := s.writer.(*bytes.Buffer); ok {
+		res = bb.Bytes()
+	}
+	return
+}
+
+func (s *htmlPainter) Reset() {
+	s.writer = new(bytes.Buffer)
+	s.inTable = false
+}
+
+func (s *htmlPainter) Flush() {
+}
+
+func (s *htmlPainter) Printf(fmtStr string, args ...interface{}) {
+	_, _ = fmt.Fprintf(s.writer, fmtStr, args...)
+}
+
+func (s *htmlPainter) closeTable() {
+	if s.inTable {
+		s.Printf("</tbody>\n</table>\n")
+		s.inTable = false
+	}
+}
+
+func (s *htmlPainter) FpPrintHeader(command *Command) {
+	s.current = command
+	s.Printf("<section class=\"command\" id=\"%v\">\n", htmlAnchor(command))
+
+	if command.IsRoot() {
+		root := command.root
+		s.Printf("<h1>%v <small class=\"version\">v%v</small></h1>\n", html.EscapeString(root.AppName), html.EscapeString(root.Version))
+		if len(root.Copyright) > 0 || len(root.Author) > 0 {
+			s.Printf("<p class=\"copyright\">%v</p>\n", html.EscapeString(strings.TrimSpace(root.Copyright+" by "+root.Author)))
+		}
+		return
+	}
+
+	// breadcrumb, links to all parents
+	var crumbs []string
+	for p := command.owner; p != nil; p = p.owner {
+		crumbs = append([]string{fmt.Sprintf("<a href=\"%v\">%v</a>", s.link(p), html.EscapeString(htmlCmdTitle(p)))}, crumbs...)
+	}
+	s.Printf("<nav class=\"breadcrumb\">%v</nav>\n", strings.Join(crumbs, " &rsaquo; "))
+
+	var class string
+	if len(command.Deprecated) > 0 {
+		class = " class=\"deprecated\""
+	}
+	s.Printf("<h2%v>%v</h2>\n", class, html.EscapeString(replaceAll(internalGetWorker().backtraceCmdNames(command), ".", " ")))
+	if len(command.Deprecated) > 0 {
+		s.Printf("<p class=\"deprecation\">%v</p>\n", html.EscapeString(T("deprecated since %v", command.Deprecated)))
+	}
+	if len(command.Short) > 0 || len(command.Aliases) > 0 {
+		s.Printf("<p class=\"aliases\">%v</p>\n", html.EscapeString(command.GetTitleNames()))
+	}
+}
+
+func (s *htmlPainter) FpPrintHelpTailLine(command *Command) {
+	s.closeTable()
+
+	var links []string
+	if command.owner != nil {
+		links = append(links, fmt.Sprintf("<li class=\"parent\"><a href=\"%v\">%v</a></li>", s.link(command.owner), html.EscapeString(htmlCmdTitle(command.owner))))
+	}
+	for _, sc := range command.SubCommands {
+		if !sc.Hidden {
+			links = append(links, fmt.Sprintf("<li class=\"child\"><a href=\"%v\">%v</a> - %v</li>", s.link(sc), html.EscapeString(htmlCmdTitle(sc)), html.EscapeString(sc.GetDescription())))
+		}
+	}
+	if len(links) > 0 {
+		s.Printf("<h3>%v</h3>\n<ul class=\"see-also\">\n%v\n</ul>\n", html.EscapeString(T("See Also")), strings.Join(links, "\n"))
+	}
+	s.Printf("</section>\n")
+}
+
+func (s *htmlPainter) FpUsagesTitle(command *Command, title string) {
+	s.Printf("<h3>%v</h3>\n", html.EscapeString(title))
+}
+
+func (s *htmlPainter) FpUsagesLine(command *Command, fmt, appName, cmdList, cmdsTitle, tailPlaceHolder string) {
+	if len(tailPlaceHolder) == 0 {
+		tailPlaceHolder = T("[tail args...]")
+	}
+	s.Printf("<pre class=\"usage\"><code class=\"language-bash\">%v</code></pre>\n",
+		html.EscapeString(strings.Join(strings.Fields(appName+" "+cmdList+cmdsTitle+tailPlaceHolder+" "+T("[Options] [Parent/Global Options]")+fmt), " ")))
+}
+
+func (s *htmlPainter) FpDescTitle(command *Command, title string) {
+	s.Printf("<h3>%v</h3>\n", html.EscapeString(title))
+}
+
+func (s *htmlPainter) FpDescLine(command *Command) {
+	desc := command.GetLongDescription()
+	if len(desc) == 0 {
+		desc = command.GetDescription()
+	}
+	s.Printf("<div class=\"description\">\n")
+	for _, para := range strings.Split(strings.TrimSpace(desc), "\n\n") {
+		if para = strings.TrimSpace(para); len(para) > 0 {
+			s.Printf("<p>%v</p>\n", html.EscapeString(para))
+		}
+	}
+	s.Printf("</div>\n")
+}
+
+func (s *htmlPainter) FpExamplesTitle(command *Command, title string) {
+	s.Printf("<h3>%v</h3>\n", html.EscapeString(title))
+}
+
+func (s *htmlPainter) FpExamplesLine(command *Command) {
+	s.Printf("%v", htmlExamples(tplApply(command.GetExamples(), command.root)))
+}
+
+func (s *htmlPainter) FpCommandsTitle(command *Command) {
+	s.Printf("<h3>%v</h3>\n", T("Sub-Commands"))
+}
+
+func (s *htmlPainter) FpCommandsGroupTitle(group string) {
+	s.closeTable()
+	if group != UnsortedGroup {
+		s.Printf("<h4>%v</h4>\n", html.EscapeString(StripOrderPrefix(group)))
+	}
+	s.Printf("<table class=\"commands\">\n<thead><tr><th>%v</th><th>%v</th><th>%v</th></tr></thead>\n<tbody>\n",
+		html.EscapeString(T("Command")), html.EscapeString(T("Aliases")), html.EscapeString(T("Description")))
+	s.inTable = true
+}
+
+func (s *htmlPainter) FpCommandsLine(command *Command) {
+	if command.Hidden {
+		return
+	}
+
+	var class, tail string
+	if len(command.Deprecated) > 0 {
+		class = " class=\"deprecated\""
+		tail = fmt.Sprintf(" <em>(%v)</em>", html.EscapeString(T("deprecated since %v", command.Deprecated)))
+	}
+	var aliases []string
+	if len(command.Short) > 0 && len(command.Full) > 0 {
+		aliases = append(aliases, command.Short)
+	}
+	aliases = append(aliases, command.Aliases...)
+	s.Printf("<tr%v><td><a href=\"%v\">%v</a></td><td>%v</td><td>%v%v</td></tr>\n",
+		class, s.link(command), html.EscapeString(htmlCmdTitle(command)),
+		html.EscapeString(strings.Join(aliases, ", ")), html.EscapeString(command.GetDescription()), tail)
+}
+
+func (s *htmlPainter) FpFlagsTitle(command *Command, flag *Flag, title string) {
+	s.closeTable()
+	s.Printf("<h3>%v</h3>\n", html.EscapeString(title))
+}
+
+func (s *htmlPainter) FpFlagsGroupTitle(group string) {
+	s.closeTable()
+	if group != UnsortedGroup {
+		s.Printf("<h4>%v</h4>\n", html.EscapeString(StripOrderPrefix(group)))
+	}
+	s.Printf("<table class=\"flags\">\n<thead><tr><th>%v</th><th>%v</th><th>%v</th></tr></thead>\n<tbody>\n",
+		html.EscapeString(T("Flag")), html.EscapeString(T("Default")), html.EscapeString(T("Description")))
+	s.inTable = true
+}
+
+func (s *htmlPainter) FpFlagsLine(command *Command, flag *Flag, defValStr string) {
+	var titles []string
+	if len(flag.Short) > 0 {
+		titles = append(titles, "-"+flag.Short)
+	}
+	if len(flag.Full) > 0 {
+		title := "--" + flag.Full
+		if len(flag.DefaultValuePlaceholder) > 0 {
+			title += "=" + flag.DefaultValuePlaceholder
+		}
+		titles = append(titles, title)
+	}
+	for _, a := range flag.Aliases {
+		titles = append(titles, "--"+a)
+	}
+
+	var class, tail string
+	if len(flag.Deprecated) > 0 {
+		class = " class=\"deprecated\""
+		tail = fmt.Sprintf(" <em>(%v)</em>", html.EscapeString(T("deprecated since %v", flag.Deprecated)))
+	}
+
+	var defVal string
+	if dv := fmt.Sprint(flag.DefaultValue); flag.DefaultValue != nil && len(dv) > 0 {
+		defVal = fmt.Sprintf("<code>%v</code>", html.EscapeString(dv))
+	}
+
+	desc := html.EscapeString(flag.GetDescription())
+	if len(flag.LongDescription) > 0 {
+		desc += "<br>" + html.EscapeString(strings.TrimSpace(flag.GetLongDescription()))
+	}
+	if len(flag.ValidArgs) > 0 {
+		desc += "<br>" + html.EscapeString(T("One of: %v", strings.Join(flag.ValidArgs, ", ")))
+	}
+	if len(flag.Examples) > 0 {
+		desc += htmlExamples(tplApply(flag.GetExamples(), command.root))
+	}
+
+	// the parent/global flags are anchored in the page of their owner
+	var id string
+	if command == s.current {
+		id = fmt.Sprintf(" id=\"%v--%v\"", htmlAnchor(command), flag.GetTitleName())
+	}
+	s.Printf("<tr%v%v><td><code>%v</code></td><td>%v</td><td>%v%v</td></tr>\n",
+		class, id, html.EscapeString(strings.Join(titles, ", ")), defVal, desc, tail)
+}
+
+// link returns the href to a command, it's an anchor in single page
+// mode, or a page with the anchor.
+func (s *htmlPainter) link(cmd *Command) string {
+	if s.singlePage {
+		return "#" + htmlAnchor(cmd)
+	}
+	return htmlPageName(cmd) + "#" + htmlAnchor(cmd)
+}
+
+// document wraps the painted body with the html page skeleton and
+// the navigation sidebar built from the whole command tree.
+func (s *htmlPainter) document(current *Command, body []byte) []byte {
+	root := internalGetWorker().rootCommand
+	title := root.AppName
+	if current != nil && !current.IsRoot() {
+		title = root.AppName + " " + replaceAll(internalGetWorker().backtraceCmdNames(current), ".", " ")
+	}
+
+	var nav bytes.Buffer
+	s.nav(&nav, &root.Command, current)
+
+	var doc bytes.Buffer
+	_, _ = fmt.Fprintf(&doc, `<!DOCTYPE html>
+<html lang="%v">
+<head>
+<meta charset="utf-8">
+<meta name="generator" content="hedzr/cmdr">
+<title>%v</title>
+<style>%v</style>
+</head>
+<body>
+<nav class="sidebar">
+%v</nav>
+<main>
+%v</main>
+<footer>Auto generated by <a href="https://github.com/hedzr/cmdr">hedzr/cmdr</a> at %v</footer>
+</body>
+</html>
+`, GetLocale(), html.EscapeString(title), htmlStyle, nav.String(), string(body), time.Now().Format("02-Jan-2006"))
+	return doc.Bytes()
+}
+
+func (s *htmlPainter) nav(w io.Writer, cmd, current *Command) {
+	var class string
+	if cmd == current {
+		class = " class=\"active\""
+	}
+	_, _ = fmt.Fprintf(w, "<ul>\n<li%v><a href=\"%v\">%v</a>\n", class, s.link(cmd), html.EscapeString(htmlCmdTitle(cmd)))
+	for _, sc := range cmd.SubCommands {
+		if !sc.Hidden {
+			s.nav(w, sc, current)
+		}
+	}
+	_, _ = fmt.Fprintf(w, "</li>\n</ul>\n")
+}
+
+func htmlCmdTitle(cmd *Command) string {
+	if cmd.IsRoot() {
+		return cmd.root.AppName
+	}
+	return cmd.GetTitleName()
+}
+
+func htmlAnchor(cmd *Command) string {
+	return "cmd-" + strings.TrimSuffix(htmlPageName(cmd), ".html")
+}
+
+func htmlPageName(cmd *Command) string {
+	fn := cmd.root.AppName
+	if !cmd.IsRoot() {
+		if cmds := replaceAll(internalGetWorker().backtraceCmdNames(cmd), ".", "-"); len(cmds) > 0 {
+			fn += "-" + cmds
+		}
+	}
+	return fn + ".html"
+}
+
+// htmlExamples highlights the examples: the command lines (leading
+// with '$ ') and the explanations are wrapped with the different
+// classes.
+func htmlExamples(examples string) string {
+	var sb strings.Builder
+	sb.WriteString("<pre class=\"examples\"><code class=\"language-bash\">")
+	for _, line := range strings.Split(strings.Trim(examples, "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.HasPrefix(strings.TrimSpace(line), "$ ") {
+			sb.WriteString(fmt.Sprintf("<span class=\"prompt\">$</span> <span class=\"cmdline\">%v</span>\n",
+				html.EscapeString(strings.TrimPrefix(strings.TrimSpace(line), "$ "))))
+		} else if len(strings.TrimSpace(line)) > 0 {
+			sb.WriteString(fmt.Sprintf("<span class=\"comment\">    %v</span>\n", html.EscapeString(strings.TrimSpace(line))))
+		}
+	}
+	sb.WriteString("</code></pre>\n")
+	return sb.String()
+}
+
+const htmlStyle = `
+body { margin: 0; display: flex; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #24292e; }
+nav.sidebar { width: 16rem; min-height: 100vh; padding: 1rem; background: #f6f8fa; border-right: 1px solid #e1e4e8; box-sizing: border-box; }
+nav.sidebar ul { list-style: none; padding-left: 1rem; margin: 0; }
+nav.sidebar > ul { padding-left: 0; }
+nav.sidebar li.active > a { font-weight: bold; }
+main { flex: 1; padding: 1rem 2rem; max-width: 60rem; }
+footer { position: fixed; bottom: 0; right: 0; padding: .25rem 1rem; font-size: small; color: #6a737d; }
+a { color: #0366d6; text-decoration: none; }
+section.command { border-bottom: 1px solid #e1e4e8; padding-bottom: 1rem; }
+.breadcrumb, .aliases, .version, .copyright { color: #6a737d; }
+.deprecated, .deprecated a { text-decoration: line-through; color: #6a737d; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; vertical-align: top; padding: .25rem .5rem; border-bottom: 1px solid #eaecef; }
+pre { background: #f6f8fa; padding: .75rem; overflow: auto; }
+.prompt { color: #6a737d; user-select: none; }
+.cmdline { color: #032f62; font-weight: bold; }
+.comment { color: #6a737d; font-style: italic; }
+`
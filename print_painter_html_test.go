@@ -0,0 +1,29 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLFlagsLine(t *testing.T) {
+	root := &RootCommand{AppName: "app"}
+	server := &Command{BaseOpt: BaseOpt{Full: "server"}, root: root}
+
+	p := newHTMLPainter(false)
+	p.FpFlagsTitle(server, nil, "Options")
+	p.FpFlagsGroupTitle(UnsortedGroup)
+	p.FpFlagsLine(server, &Flag{BaseOpt: BaseOpt{Full: "mode", Description: "<mode> & more"}, DefaultValue: "a&b", ValidArgs: []string{"a&b", "<c>"}}, "")
+	p.FpPrintHelpTailLine(server)
+
+	out := string(p.Results())
+	for _, s := range []string{"&lt;mode&gt; &amp; more", "<code>a&amp;b</code>", "One of: a&amp;b, &lt;c&gt;"} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("expecting %q in the html:\n%v", s, out)
+		}
+	}
+	if strings.Contains(out, "<mode>") || strings.Contains(out, "&amp;amp;") {
+		t.Fatalf("the html isn't escaped exactly once:\n%v", out)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -61,7 +63,7 @@ func (s *manPainter) FpPrintHeader(command *Command) {
 	a := &manHdrData{
 		*root,
 		time.Now().Format("Jan 2006"),
-		manExamples(root.Examples, root),
+		manExamples(root.GetExamples(), root),
 	}
 
 	s.Printf("%v", tplApply(`
@@ -70,7 +72,7 @@ func (s *manPainter) FpPrintHeader(command *Command) {
 .TH {{.AppName}} 1 "{{.TimeMY}}" "{{.Version}}" "Tool with cmdr"
 Auto generated by hedzr/cmdr
 
-.SH NAME
+.SH {{T "NAME"}}
 .PP
 {{.AppName}} v{{.Version}} - {{.Copyright}}
 
@@ -79,15 +81,15 @@ Auto generated by hedzr/cmdr
 	if command.IsRoot() {
 		s.Printf("%v", tplApply(`
 
-.SH SYNOPSIS
+.SH {{T "SYNOPSIS"}}
 .PP
 \fB{{.AppName}} generate manual [flags]\fP
 
-.SH DESCRIPTIONS
+.SH {{T "DESCRIPTIONS"}}
 .PP
-{{.LongDescription}}
+{{.GetLongDescription}}
 
-.SH EXAMPLES
+.SH {{T "EXAMPLES"}}
 
 {{.ManExamples}}
 
@@ -102,20 +104,47 @@ Auto generated by hedzr/cmdr
 
 func (s *manPainter) FpPrintHelpTailLine(command *Command) {
 	root := command.root
+
+	// cross-references to the pages of the parent and the sub-commands
+	refs := []string{fmt.Sprintf("\\fB%v\\fP(1)", root.AppName)}
+	if command.owner != nil && !command.owner.IsRoot() {
+		refs = append(refs, fmt.Sprintf("\\fB%v\\fP(1)", manPageName(command.owner)))
+	}
+	for _, sc := range command.SubCommands {
+		if !sc.Hidden {
+			refs = append(refs, fmt.Sprintf("\\fB%v\\fP(1)", manPageName(sc)))
+		}
+	}
+	if command.IsRoot() {
+		refs = refs[1:]
+	}
+
 	s.Printf(`
-.SH SEE ALSO
+.SH %v
 .PP
-\fB%v(1)\fP
+%v
 
-.SH HISTORY
+.SH %v
 .PP
 %v Auto generated by hedzr/cmdr
-`, root.AppName, time.Now().Format("02-Jan-2006")) // , time.RFC822Z
+`, T("SEE ALSO"), strings.Join(refs, ",\n"), T("HISTORY"), time.Now().Format("02-Jan-2006")) // , time.RFC822Z
+}
+
+// manPageName returns the name of the man page of a command, such as
+// 'app-server-start'.
+func manPageName(cmd *Command) (fn string) {
+	fn = cmd.root.AppName
+	if !cmd.IsRoot() {
+		if cmds := replaceAll(internalGetWorker().backtraceCmdNames(cmd), ".", "-"); len(cmds) > 0 {
+			fn += "-" + cmds
+		}
+	}
+	return
 }
 
 func (s *manPainter) FpUsagesTitle(command *Command, title string) {
 	if !command.IsRoot() {
-		s.Printf("\n.SH %s\n", "SYNOPSIS")
+		s.Printf("\n.SH %s\n", T("SYNOPSIS"))
 	}
 	// s.Printf("\n\x1b[%dm\x1b[%dm%s\x1b[0m", bgNormal, darkColor, title)
 	// fp("  [\x1b[%dm\x1b[%dm%s\x1b[0m]", bgDim, darkColor, normalize(group))
@@ -126,9 +155,9 @@ func (s *manPainter) FpUsagesLine(command *Command, fmt, appName, cmdList, cmdsT
 		if len(tailPlaceHolder) > 0 {
 			tailPlaceHolder = command.TailPlaceHolder
 		} else {
-			tailPlaceHolder = "[tail args...]"
+			tailPlaceHolder = T("[tail args...]")
 		}
-		s.Printf(".PP\n\\fB%s\\fP %v%s%s [Options] [Parent/Global Options]"+fmt+"\n\n", appName, cmdList, cmdsTitle, tailPlaceHolder)
+		s.Printf(".PP\n\\fB%s\\fP %v%s%s %v"+fmt+"\n\n", appName, cmdList, cmdsTitle, tailPlaceHolder, T("[Options] [Parent/Global Options]"))
 	}
 }
 
@@ -145,9 +174,9 @@ func (s *manPainter) FpDescTitle(command *Command, title string) {
 func (s *manPainter) FpDescLine(command *Command) {
 	if !command.IsRoot() {
 		if len(command.LongDescription) > 0 {
-			s.Printf(".PP\n%v\n", manBr(command.LongDescription))
+			s.Printf(".PP\n%v\n", manBr(command.GetLongDescription()))
 		} else if len(command.Description) > 0 {
-			s.Printf(".PP\n%v\n", command.Description)
+			s.Printf(".PP\n%v\n", command.GetDescription())
 		}
 	}
 }
@@ -163,14 +192,14 @@ func (s *manPainter) FpExamplesTitle(command *Command, title string) {
 func (s *manPainter) FpExamplesLine(command *Command) {
 	if !command.IsRoot() {
 		if len(command.Examples) > 0 && command.HasParent() {
-			s.Printf("%v\n", manExamples(command.Examples, command.root))
+			s.Printf("%v\n", manExamples(command.GetExamples(), command.root))
 		}
 	}
 }
 
 func (s *manPainter) FpCommandsTitle(command *Command) {
 	var title string
-	title = "COMMANDS AND SUB-COMMANDS"
+	title = T("COMMANDS AND SUB-COMMANDS")
 	// if command.HasParent() {
 	// 	title = "Commands"
 	// } else {
@@ -184,7 +213,7 @@ func (s *manPainter) FpCommandsGroupTitle(group string) {
 		// fp("  [%s]:", normalize(group))
 		s.Printf(".SS \"%s\"\n", StripOrderPrefix(group))
 	} else {
-		s.Printf(".SS \"%s\"\n", "General")
+		s.Printf(".SS \"%s\"\n", T("General"))
 	}
 }
 
@@ -193,26 +222,180 @@ func (s *manPainter) FpCommandsLine(command *Command) {
 		// s.Printf("  %-48s%v", command.GetTitleNames(), command.Description)
 		// s.Printf("\n\x1b[%dm\x1b[%dm%s\x1b[0m", bgNormal, darkColor, title)
 		// s.Printf("  [\x1b[%dm\x1b[%dm%s\x1b[0m]", bgDim, darkColor, normalize(group))
-		s.Printf(".TP\n.BI %s\n%s\n", manWs(command.GetTitleNames()), command.Description)
+		s.Printf(".TP\n.BI %s\n%s\n", manWs(command.GetTitleNames()), command.GetDescription())
 	}
 }
 
 func (s *manPainter) FpFlagsTitle(command *Command, flag *Flag, title string) {
-	s.Printf("\n.SH %s\n", "OPTIONS")
+	s.Printf("\n.SH %s\n", T("OPTIONS"))
 }
 
 func (s *manPainter) FpFlagsGroupTitle(group string) {
 	if group != UnsortedGroup {
 		s.Printf(".SS \"%s\"\n", StripOrderPrefix(group))
 	} else {
-		s.Printf(".SS \"%s\"\n", "General")
+		s.Printf(".SS \"%s\"\n", T("General"))
 	}
 }
 
 func (s *manPainter) FpFlagsLine(command *Command, flag *Flag, defValStr string) {
-	s.Printf(".TP\n.BI %s\n%s\n%s\n", manWs(flag.GetTitleFlagNames()), flag.Description, defValStr)
+	s.Printf(".TP\n.BI %s\n%s\n%s\n", manWs(flag.GetTitleFlagNames()), flag.GetDescription(), defValStr)
+	if flagBindingsEnabled() {
+		s.Printf(".br\n\\fI%v\\fP\n", getFlagBinding(flag))
+	}
 }
 
 //
 //
 //
+
+// paintSinglePage paints the whole command tree into one man page,
+// with the sections SUBCOMMANDS, ENVIRONMENT, FILES and SEE ALSO.
+func (s *manPainter) paintSinglePage(w *ExecWorker) {
+	root := &w.rootCommand.Command
+	s.FpPrintHeader(root)
+	printHelpFlagSections(s, root, false)
+
+	var cmds []*Command
+	_ = walkVisibleCommands(root, 0, func(cmd *Command, index int) (err error) {
+		if !cmd.IsRoot() {
+			cmds = append(cmds, cmd)
+		}
+		return
+	})
+
+	if len(cmds) > 0 {
+		s.Printf("\n.SH %v\n", T("SUBCOMMANDS"))
+		for _, cmd := range cmds {
+			s.paintSubCommand(cmd)
+		}
+	}
+
+	s.paintEnvironment(root)
+	s.paintFiles(w)
+
+	s.Printf("\n.SH %v\n", T("SEE ALSO"))
+	for _, cmd := range root.SubCommands {
+		if !cmd.Hidden {
+			s.Printf(".TP\n.B %v\n%v\n", manWs(manCmdTitle(cmd)), cmd.GetDescription())
+		}
+	}
+
+	s.Printf(`
+.SH %v
+.PP
+%v Auto generated by hedzr/cmdr
+`, T("HISTORY"), time.Now().Format("02-Jan-2006"))
+}
+
+func (s *manPainter) paintSubCommand(cmd *Command) {
+	s.Printf(".SS \"%v\"\n", manCmdTitle(cmd))
+	if len(cmd.Deprecated) > 0 {
+		s.Printf(".PP\n\\fI%v\\fP\n", T("deprecated since %v", cmd.Deprecated))
+	}
+
+	ttl := ""
+	if len(cmd.SubCommands) > 0 {
+		ttl = " " + T("[Sub-Commands]")
+	}
+	tail := cmd.TailPlaceHolder
+	if len(tail) == 0 {
+		tail = T("[tail args...]")
+	}
+	s.Printf(".PP\n\\fB%v\\fP%v %v %v\n", manCmdTitle(cmd), ttl, tail, T("[Options]"))
+	if len(cmd.Short) > 0 || len(cmd.Aliases) > 0 {
+		s.Printf(".PP\n%v\n", T("Names: %v", cmd.GetTitleNames()))
+	}
+
+	if len(cmd.LongDescription) > 0 {
+		s.Printf(".PP\n%v\n", manBr(cmd.GetLongDescription()))
+	} else if len(cmd.Description) > 0 {
+		s.Printf(".PP\n%v\n", cmd.GetDescription())
+	}
+	if len(cmd.Examples) > 0 {
+		s.Printf(".PP\n%v:\n%v\n", T("Examples"), manExamples(cmd.GetExamples(), cmd.root))
+	}
+
+	var flags []*Flag
+	for _, flg := range cmd.Flags {
+		if !flg.Hidden {
+			flags = append(flags, flg)
+		}
+	}
+	if len(flags) > 0 {
+		s.Printf(".PP\n%v:\n", T("Options"))
+		for _, flg := range flags {
+			s.FpFlagsLine(cmd, flg, flagDefaultValueString(flg))
+		}
+	}
+
+	var refs []string
+	if !cmd.owner.IsRoot() {
+		refs = append(refs, fmt.Sprintf("\\fB%v\\fP", manCmdTitle(cmd.owner)))
+	}
+	for _, sc := range cmd.SubCommands {
+		if !sc.Hidden {
+			refs = append(refs, fmt.Sprintf("\\fB%v\\fP", manCmdTitle(sc)))
+		}
+	}
+	if len(refs) > 0 {
+		s.Printf(".PP\n%v\n", T("See also: %v", strings.Join(refs, ", ")))
+	}
+}
+
+// paintEnvironment lists all environment variables bound to the flags.
+func (s *manPainter) paintEnvironment(root *Command) {
+	envs := make(map[string][]string)
+	_ = walkFromCommand(root, 0, func(cmd *Command, index int) (err error) {
+		for _, flg := range cmd.Flags {
+			for _, env := range flg.EnvVars {
+				if len(env) > 0 {
+					envs[env] = uniAddStr(envs[env], fmt.Sprintf("%v (\\fB%v\\fP)", strings.Join(strings.Fields(flg.GetTitleFlagNames()), " "), manCmdTitle(cmd)))
+				}
+			}
+		}
+		return
+	})
+	if len(envs) == 0 {
+		return
+	}
+
+	var keys []string
+	for k := range envs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	s.Printf("\n.SH %v\n", T("ENVIRONMENT"))
+	for _, k := range keys {
+		s.Printf(".TP\n.B %v\n%v\n", k, T("Overrides %v", strings.Join(envs[k], ", ")))
+	}
+}
+
+// paintFiles lists the predefined locations of the config files.
+func (s *manPainter) paintFiles(w *ExecWorker) {
+	if len(w.predefinedLocations) == 0 {
+		return
+	}
+
+	appName := w.rootCommand.AppName
+	s.Printf("\n.SH %v\n.PP\n", T("FILES"))
+	for _, loc := range w.predefinedLocations {
+		fn := loc
+		switch strings.Count(fn, "%s") {
+		case 2:
+			fn = fmt.Sprintf(loc, appName, appName)
+		case 1:
+			fn = fmt.Sprintf(loc, appName)
+		}
+		s.Printf(".I %v\n.br\n", fn)
+	}
+	s.Printf(".PP\n%v\n", T("The first config file found will be loaded, and the files in the \\fIconf.d\\fP directory beside it will be merged too."))
+}
+
+func manCmdTitle(cmd *Command) string {
+	if cmd.IsRoot() {
+		return cmd.root.AppName
+	}
+	return cmd.root.AppName + " " + replaceAll(internalGetWorker().backtraceCmdNames(cmd), ".", " ")
+}
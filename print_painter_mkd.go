@@ -16,6 +16,10 @@ type (
 	markdownPainter struct {
 		writer io.Writer
 		// buffer bufio.Writer
+
+		// singlePage paints the commands as the sections of one
+		// document, see also genDocMarkdownSingle().
+		singlePage bool
 	}
 )
 
@@ -59,11 +63,23 @@ type mkdHdrData struct {
 
 func (s *markdownPainter) FpPrintHeader(command *Command) {
 	root := command.root
+	if s.singlePage {
+		// the anchor must not be escaped by Printf
+		_, _ = fmt.Fprintf(s.writer, "\n<a id=\"%v\"></a>\n", manPageName(command))
+		if !command.IsRoot() {
+			s.Printf("\n## %v\n\n", manCmdTitle(command))
+			if len(command.Deprecated) > 0 {
+				s.Printf("> %v\n\n", T("deprecated since %v", command.Deprecated))
+			}
+			return
+		}
+	}
+
 	a := &mkdHdrData{
 		*root,
 		time.Now().Format("Jan 2006"),
 		// manExamples(root.Examples, root),
-		fmt.Sprintf("\n```bash\n%v\n```\n", tplApply(root.Examples, root)),
+		fmt.Sprintf("\n```bash\n%v\n```\n", tplApply(root.GetExamples(), root)),
 	}
 
 	s.Printf("%v", tplApply(`
@@ -76,15 +92,15 @@ func (s *markdownPainter) FpPrintHeader(command *Command) {
 	if command.IsRoot() {
 		s.Printf("%v", tplApply(`
 
-### SYNOPSIS
+### {{T "SYNOPSIS"}}
 
 **{{.AppName}} generate manual [flags]**
 
-### DESCRIPTIONS
+### {{T "DESCRIPTIONS"}}
 
-{{.LongDescription}}
+{{.GetLongDescription}}
 
-### EXAMPLES
+### {{T "EXAMPLES"}}
 
 {{.ManExamples}}
 
@@ -93,18 +109,34 @@ func (s *markdownPainter) FpPrintHeader(command *Command) {
 }
 
 func (s *markdownPainter) FpPrintHelpTailLine(command *Command) {
+	if s.singlePage {
+		var links []string
+		if command.owner != nil {
+			links = append(links, fmt.Sprintf("* [**%v**](#%v)", manCmdTitle(command.owner), manPageName(command.owner)))
+		}
+		for _, sc := range command.SubCommands {
+			if !sc.Hidden {
+				links = append(links, fmt.Sprintf("* [**%v**](#%v) - *%v*", manCmdTitle(sc), manPageName(sc), sc.GetDescription()))
+			}
+		}
+		if len(links) > 0 {
+			s.Printf("\n### %v\n\n%v\n", T("SEE ALSO"), strings.Join(links, "\n"))
+		}
+		return
+	}
+
 	// root := command.root
 	s.Printf(`
-### SEE ALSO
+### %v
 
 %v
 
-### HISTORY
+### %v
 
 [^1]: %v Auto generated by [hedzr/cmdr](https://github.com/hedzr/cmdr)
 
 `,
-		strings.Join(mkdSubCommands(command), "\n"),
+		T("SEE ALSO"), strings.Join(mkdSubCommands(command), "\n"), T("HISTORY"),
 		time.Now().Format("02-Jan-2006")) // , time.RFC822Z
 }
 
@@ -119,16 +151,16 @@ func mkdSubCommands(command *Command) (ret []string) {
 		var wrapChars, tail string
 		if len(sc.Deprecated) > 0 {
 			wrapChars = "~~"
-			tail = fmt.Sprintf(" (deprecated since %v)", sc.Deprecated)
+			tail = " (" + T("deprecated since %v", sc.Deprecated) + ")"
 		}
-		ret = append(ret, fmt.Sprintf("* [%s**%v**%s](%v.md) - *%v*%v", wrapChars, title, wrapChars, title, sc.Description, tail))
+		ret = append(ret, fmt.Sprintf("* [%s**%v**%s](%v.md) - *%v*%v", wrapChars, title, wrapChars, title, sc.GetDescription(), tail))
 	}
 	return
 }
 
 func (s *markdownPainter) FpUsagesTitle(command *Command, title string) {
 	if !command.IsRoot() {
-		s.Printf("\n### %s\n", "SYNOPSIS")
+		s.Printf("\n### %s\n", T("SYNOPSIS"))
 	}
 	// s.Printf("\n\x1b[%dm\x1b[%dm%s\x1b[0m", bgNormal, darkColor, title)
 	// fp("  [\x1b[%dm\x1b[%dm%s\x1b[0m]", bgDim, darkColor, normalize(group))
@@ -139,10 +171,10 @@ func (s *markdownPainter) FpUsagesLine(command *Command, fmt, appName, cmdList,
 		if len(tailPlaceHolder) > 0 {
 			tailPlaceHolder = command.TailPlaceHolder
 		} else {
-			tailPlaceHolder = "[tail args...]"
+			tailPlaceHolder = T("[tail args...]")
 		}
-		s.Printf("```bash\n%s %v%s%s [Options] [Parent/Global Options]"+fmt+"\n```\n",
-			appName, cmdList, cmdsTitle, tailPlaceHolder)
+		s.Printf("```bash\n%s %v%s%s %v"+fmt+"\n```\n",
+			appName, cmdList, cmdsTitle, tailPlaceHolder, T("[Options] [Parent/Global Options]"))
 	}
 }
 
@@ -159,9 +191,9 @@ func (s *markdownPainter) FpDescTitle(command *Command, title string) {
 func (s *markdownPainter) FpDescLine(command *Command) {
 	if !command.IsRoot() {
 		if len(command.LongDescription) > 0 {
-			s.Printf("\n%v\n", command.LongDescription)
+			s.Printf("\n%v\n", command.GetLongDescription())
 		} else if len(command.Description) > 0 {
-			s.Printf("\n%v\n", command.Description)
+			s.Printf("\n%v\n", command.GetDescription())
 		}
 	}
 }
@@ -177,14 +209,14 @@ func (s *markdownPainter) FpExamplesTitle(command *Command, title string) {
 func (s *markdownPainter) FpExamplesLine(command *Command) {
 	if !command.IsRoot() {
 		if len(command.Examples) > 0 && command.HasParent() {
-			s.Printf("\n```bash\n%v\n```\n", tplApply(command.Examples, command.root))
+			s.Printf("\n```bash\n%v\n```\n", tplApply(command.GetExamples(), command.root))
 		}
 	}
 }
 
 func (s *markdownPainter) FpCommandsTitle(command *Command) {
 	var title string
-	title = "SUB-COMMANDS"
+	title = T("SUB-COMMANDS")
 	// if command.HasParent() {
 	// 	title = "Commands"
 	// } else {
@@ -198,7 +230,7 @@ func (s *markdownPainter) FpCommandsGroupTitle(group string) {
 		// fp("  [%s]:", normalize(group))
 		s.Printf("#### %s\n", StripOrderPrefix(group))
 	} else {
-		s.Printf("#### %s\n", "General")
+		s.Printf("#### %s\n", T("General"))
 	}
 }
 
@@ -216,26 +248,26 @@ func (s *markdownPainter) FpCommandsLine(command *Command) {
 		var wrapChars, tail string
 		if len(command.Deprecated) > 0 {
 			wrapChars = "~~"
-			tail = fmt.Sprintf("> deprecated since %v", command.Deprecated)
+			tail = "> " + T("deprecated since %v", command.Deprecated)
 		}
 
 		s.Printf("##### %s%s%s", wrapChars, title, wrapChars)
 		if len(command.Short) > 0 && len(command.Full) > 0 {
-			s.Printf(" (**Short**: %v) ", command.Short)
+			s.Printf(" (**%v**: %v) ", T("Short"), command.Short)
 		}
 		if len(command.Aliases) > 0 {
-			s.Printf(" (**Aliases**: %v) ", command.Aliases)
+			s.Printf(" (**%v**: %v) ", T("Aliases"), command.Aliases)
 		}
 		s.Printf("\n\n%v\n\n", tail)
 
 		if len(command.Description) > 0 {
-			s.Printf("%v\n\n", command.Description)
+			s.Printf("%v\n\n", command.GetDescription())
 		}
 		if len(command.LongDescription) > 0 {
-			s.Printf("%v\n\n", command.LongDescription)
+			s.Printf("%v\n\n", command.GetLongDescription())
 		}
 		if len(command.Examples) > 0 {
-			s.Printf("```bash\n%v\n```\n", tplApply(command.Examples, command.root))
+			s.Printf("```bash\n%v\n```\n", tplApply(command.GetExamples(), command.root))
 		}
 	}
 }
@@ -248,7 +280,7 @@ func (s *markdownPainter) FpFlagsGroupTitle(group string) {
 	if group != UnsortedGroup {
 		s.Printf("#### %s\n", StripOrderPrefix(group))
 	} else {
-		s.Printf("#### %s\n", "General")
+		s.Printf("#### %s\n", T("General"))
 	}
 }
 
@@ -263,7 +295,7 @@ func (s *markdownPainter) FpFlagsLine(command *Command, flag *Flag, defValStr st
 	var wrapChars, tail string
 	if len(flag.Deprecated) > 0 {
 		wrapChars = "~~"
-		tail = fmt.Sprintf("> deprecated since %v", flag.Deprecated)
+		tail = "> " + T("deprecated since %v", flag.Deprecated)
 	}
 
 	s.Printf("##### %s%s%s %s ", wrapChars, title, wrapChars, flag.DefaultValuePlaceholder)
@@ -278,18 +310,32 @@ func (s *markdownPainter) FpFlagsLine(command *Command, flag *Flag, defValStr st
 		s.Printf(" (**Aliases**: %v) ", tt)
 	}
 	s.Printf("\n\n%v\n\n%v\n\n", defValStr, tail)
+	if flagBindingsEnabled() {
+		s.Printf("`%v`\n\n", getFlagBinding(flag))
+	}
 
 	if len(flag.Description) > 0 {
-		s.Printf("%v\n\n", flag.Description)
+		s.Printf("%v\n\n", flag.GetDescription())
 	}
 	if len(flag.LongDescription) > 0 {
-		s.Printf("%v\n\n", flag.LongDescription)
+		s.Printf("%v\n\n", flag.GetLongDescription())
 	}
 	if len(flag.Examples) > 0 {
-		s.Printf("```bash\n%v\n```\n", tplApply(flag.Examples, command.root))
+		s.Printf("```bash\n%v\n```\n", tplApply(flag.GetExamples(), command.root))
 	}
 }
 
 //
 //
 //
+
+// markdownTOC returns the table of contents for a single page
+// markdown document.
+func markdownTOC(root *Command) string {
+	var sb strings.Builder
+	_ = walkVisibleCommands(root, 0, func(cmd *Command, index int) (err error) {
+		sb.WriteString(fmt.Sprintf("%v- [%v](#%v)\n", strings.Repeat("  ", findDepth(cmd)-1), manCmdTitle(cmd), manPageName(cmd)))
+		return
+	})
+	return sb.String()
+}
@@ -0,0 +1,24 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"testing"
+)
+
+func TestMarkdownTOC(t *testing.T) {
+	root := &RootCommand{AppName: "toc-app"}
+	root.root = root
+	server := &Command{BaseOpt: BaseOpt{Full: "server", owner: &root.Command}, root: root}
+	start := &Command{BaseOpt: BaseOpt{Full: "start", owner: server}, root: root}
+	debug := &Command{BaseOpt: BaseOpt{Full: "debug", Hidden: true, owner: &root.Command}, root: root}
+	dump := &Command{BaseOpt: BaseOpt{Full: "dump", owner: debug}, root: root}
+	server.SubCommands = []*Command{start}
+	debug.SubCommands = []*Command{dump}
+	root.SubCommands = []*Command{server, debug}
+
+	expect := "- [toc-app](#toc-app)\n  - [toc-app server](#toc-app-server)\n    - [toc-app server start](#toc-app-server-start)\n"
+	if toc := markdownTOC(&root.Command); toc != expect {
+		t.Fatalf("the hidden command and its sub-commands should be skipped, expect:\n%v\nbut got:\n%v", expect, toc)
+	}
+}
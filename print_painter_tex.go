@@ -0,0 +1,286 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type (
+	// texPainter paints a command as a LaTeX fragment, all fragments
+	// will be assembled into one document by document().
+	texPainter struct {
+		writer io.Writer
+		// pendingTitle and pendingGroup will be printed lazily while
+		// the first item of a list coming, so that there are no empty
+		// description lists which cannot be compiled by TeX.
+		pendingTitle string
+		pendingGroup string
+		inList       bool
+	}
+)
+
+func newTexPainter() *texPainter {
+	return &texPainter{
+		writer: new(bytes.Buffer),
+	}
+}
+
+func (s *texPainter) Results() (res []byte) {
+	if bb, ok := s.writer.(*bytes.Buffer); ok {
+		res = bb.Bytes()
+	}
+	return
+}
+
+func (s *texPainter) Reset() {
+	s.writer = new(bytes.Buffer)
+	s.pendingTitle, s.pendingGroup, s.inList = "", "", false
+}
+
+func (s *texPainter) Flush() {
+}
+
+func (s *texPainter) Printf(fmtStr string, args ...interface{}) {
+	_, _ = fmt.Fprintf(s.writer, fmtStr, args...)
+}
+
+func (s *texPainter) closeList() {
+	if s.inList {
+		s.Printf("\\end{description}\n")
+		s.inList = false
+	}
+}
+
+func (s *texPainter) openList() {
+	if len(s.pendingTitle) > 0 {
+		s.Printf("\\cmdrhead{%v}\n", texEscape(s.pendingTitle))
+		s.pendingTitle = ""
+	}
+	if len(s.pendingGroup) > 0 {
+		s.Printf("\\cmdrgroup{%v}\n", texEscape(s.pendingGroup))
+		s.pendingGroup = ""
+	}
+	if !s.inList {
+		s.Printf("\\begin{description}\n")
+		s.inList = true
+	}
+}
+
+func (s *texPainter) FpPrintHeader(command *Command) {
+	if command.IsRoot() {
+		s.Printf("\\label{%v}\n", texLabel(command))
+		return
+	}
+
+	sections := []string{"section", "subsection", "subsubsection"}
+	sec := "paragraph"
+	if d := findDepth(command) - 2; d < len(sections) {
+		sec = sections[d]
+	}
+	title := texEscape(replaceAll(internalGetWorker().backtraceCmdNames(command), ".", " "))
+	s.Printf("\n\\%v{%v}\\label{%v}\n", sec, title, texLabel(command))
+	if len(command.Deprecated) > 0 {
+		s.Printf("\\emph{%v}\n\n", texEscape(T("deprecated since %v", command.Deprecated)))
+	}
+	if len(command.Short) > 0 || len(command.Aliases) > 0 {
+		s.Printf("%v\\texttt{%v}\n\n", texEscape(T("Names: %v", "")), texEscape(command.GetTitleNames()))
+	}
+}
+
+func (s *texPainter) FpPrintHelpTailLine(command *Command) {
+	s.closeList()
+	if command.owner != nil {
+		s.Printf("\n%v (section~\\ref{%v}).\n", texEscape(T("See also: %v", texCmdTitle(command.owner))), texLabel(command.owner))
+	}
+}
+
+func (s *texPainter) FpUsagesTitle(command *Command, title string) {
+	s.Printf("\\cmdrhead{%v}\n", texEscape(title))
+}
+
+func (s *texPainter) FpUsagesLine(command *Command, fmt, appName, cmdList, cmdsTitle, tailPlaceHolder string) {
+	if len(tailPlaceHolder) == 0 {
+		tailPlaceHolder = T("[tail args...]")
+	}
+	s.Printf("\\begin{verbatim}\n%v\n\\end{verbatim}\n",
+		texVerbatim(strings.Join(strings.Fields(appName+" "+cmdList+cmdsTitle+tailPlaceHolder+" "+T("[Options] [Parent/Global Options]")+fmt), " ")))
+}
+
+func (s *texPainter) FpDescTitle(command *Command, title string) {
+	s.Printf("\\cmdrhead{%v}\n", texEscape(title))
+}
+
+func (s *texPainter) FpDescLine(command *Command) {
+	desc := command.GetLongDescription()
+	if len(desc) == 0 {
+		desc = command.GetDescription()
+	}
+	s.Printf("%v\n\n", texEscape(strings.TrimSpace(desc)))
+}
+
+func (s *texPainter) FpExamplesTitle(command *Command, title string) {
+	s.Printf("\\cmdrhead{%v}\n", texEscape(title))
+}
+
+func (s *texPainter) FpExamplesLine(command *Command) {
+	s.Printf("\\begin{verbatim}\n%v\n\\end{verbatim}\n", texVerbatim(tplApply(command.GetExamples(), command.root)))
+}
+
+func (s *texPainter) FpCommandsTitle(command *Command) {
+	s.closeList()
+	s.pendingTitle = T("Sub-Commands")
+}
+
+func (s *texPainter) FpCommandsGroupTitle(group string) {
+	s.closeList()
+	s.pendingGroup = ""
+	if group != UnsortedGroup {
+		s.pendingGroup = StripOrderPrefix(group)
+	}
+}
+
+func (s *texPainter) FpCommandsLine(command *Command) {
+	if command.Hidden {
+		return
+	}
+
+	s.openList()
+	s.Printf("\\item[\\texttt{%v}] %v", texEscape(command.GetTitleNames()), texEscape(command.GetDescription()))
+	if len(command.Deprecated) > 0 {
+		s.Printf(" \\emph{(%v)}", texEscape(T("deprecated since %v", command.Deprecated)))
+	}
+	s.Printf(" (section~\\ref{%v})\n", texLabel(command))
+}
+
+func (s *texPainter) FpFlagsTitle(command *Command, flag *Flag, title string) {
+	s.closeList()
+	s.pendingTitle = title
+}
+
+func (s *texPainter) FpFlagsGroupTitle(group string) {
+	s.closeList()
+	s.pendingGroup = ""
+	if group != UnsortedGroup {
+		s.pendingGroup = StripOrderPrefix(group)
+	}
+}
+
+func (s *texPainter) FpFlagsLine(command *Command, flag *Flag, defValStr string) {
+	var titles []string
+	if len(flag.Short) > 0 {
+		titles = append(titles, "-"+flag.Short)
+	}
+	if len(flag.Full) > 0 {
+		title := "--" + flag.Full
+		if len(flag.DefaultValuePlaceholder) > 0 {
+			title += "=" + flag.DefaultValuePlaceholder
+		}
+		titles = append(titles, title)
+	}
+	for _, a := range flag.Aliases {
+		titles = append(titles, "--"+a)
+	}
+
+	s.openList()
+	s.Printf("\\item[\\texttt{%v}] %v", texEscape(strings.Join(titles, ", ")), texEscape(flag.GetDescription()))
+	if len(flag.Deprecated) > 0 {
+		s.Printf(" \\emph{(%v)}", texEscape(T("deprecated since %v", flag.Deprecated)))
+	}
+	if len(flag.LongDescription) > 0 {
+		s.Printf("\n\n%v", texEscape(strings.TrimSpace(flag.GetLongDescription())))
+	}
+	if len(flag.ValidArgs) > 0 {
+		s.Printf("\n\n%v", texEscape(T("One of: %v", strings.Join(flag.ValidArgs, ", "))))
+	}
+	if dv := fmt.Sprint(flag.DefaultValue); flag.DefaultValue != nil && len(dv) > 0 {
+		s.Printf("\n\n%v: \\texttt{%v}", texEscape(T("Default")), texEscape(dv))
+	}
+	s.Printf("\n")
+	if len(flag.Examples) > 0 {
+		s.Printf("\\begin{verbatim}\n%v\n\\end{verbatim}\n", texVerbatim(tplApply(flag.GetExamples(), command.root)))
+	}
+}
+
+// document wraps the painted body with the preamble of a LaTeX
+// article.
+func (s *texPainter) document(body []byte) []byte {
+	root := internalGetWorker().rootCommand
+
+	var doc bytes.Buffer
+	_, _ = fmt.Fprintf(&doc, `%% Auto generated by hedzr/cmdr (https://github.com/hedzr/cmdr)
+\documentclass{article}
+\usepackage[T1]{fontenc}
+\usepackage[utf8]{inputenc}
+\usepackage{hyperref}
+
+\newcommand{\cmdrhead}[1]{\par\medskip\noindent\textbf{#1}\par\smallskip}
+\newcommand{\cmdrgroup}[1]{\par\noindent\textit{#1}\par}
+
+\title{%v v%v}
+\author{%v}
+\date{\today}
+
+\begin{document}
+\maketitle
+
+%v
+
+\tableofcontents
+
+%v
+\end{document}
+`, texEscape(root.AppName), texEscape(root.Version), texEscape(root.Author), texEscape(root.Copyright), string(body))
+	return doc.Bytes()
+}
+
+func texCmdTitle(cmd *Command) string {
+	if cmd.IsRoot() {
+		return cmd.root.AppName
+	}
+	return replaceAll(internalGetWorker().backtraceCmdNames(cmd), ".", " ")
+}
+
+func texLabel(cmd *Command) string {
+	label := "cmd:" + cmd.root.AppName
+	if !cmd.IsRoot() {
+		label += "-" + replaceAll(internalGetWorker().backtraceCmdNames(cmd), ".", "-")
+	}
+	// the labels are used as is, so keep the safe characters only
+	return strings.Map(func(r rune) rune {
+		if r < 0x80 && (r == ':' || r == '-' || r == '.' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')) {
+			return r
+		}
+		return '-'
+	}, label)
+}
+
+var texReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`$`, `\$`,
+	`&`, `\&`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`%`, `\%`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	`<`, `\textless{}`,
+	`>`, `\textgreater{}`,
+	`|`, `\textbar{}`,
+	`--`, `-{}-`,
+)
+
+// texEscape escapes the special characters of TeX.
+func texEscape(s string) string {
+	return texReplacer.Replace(s)
+}
+
+// texVerbatim makes sure the text cannot close the verbatim block.
+func texVerbatim(s string) string {
+	return replaceAll(strings.Trim(s, "\n"), `\end{verbatim}`, `\end {verbatim}`)
+}
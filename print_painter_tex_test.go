@@ -0,0 +1,29 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTexFlagsLine(t *testing.T) {
+	root := &RootCommand{AppName: "app"}
+	server := &Command{BaseOpt: BaseOpt{Full: "server"}, root: root}
+
+	p := newTexPainter()
+	p.FpFlagsTitle(server, nil, "Options")
+	p.FpFlagsGroupTitle(UnsortedGroup)
+	p.FpFlagsLine(server, &Flag{BaseOpt: BaseOpt{Full: "rate_limit", Description: "100% of {a} & #1 costs $5"}, ValidArgs: []string{"x_y", "$z"}}, "")
+	p.FpPrintHelpTailLine(server)
+
+	out := string(p.Results())
+	for _, s := range []string{`\texttt{-{}-rate\_limit}`, `100\% of \{a\} \& \#1 costs \$5`, `One of: x\_y, \$z`} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("expecting %q in the tex:\n%v", s, out)
+		}
+	}
+	if strings.Contains(out, `\textbackslash{}`) {
+		t.Fatalf("the tex isn't escaped exactly once:\n%v", out)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type (
+	// searchHit is a command or a flag matched by `--help-search`
+	searchHit struct {
+		cmd     *Command
+		flg     *Flag
+		score   float64
+		field   string
+		snippet string
+	}
+
+	// searchField is a searchable text of a command or a flag, the
+	// weight ranks the name matches over the description matches.
+	searchField struct {
+		name   string
+		text   string
+		weight float64
+	}
+)
+
+const (
+	// searchFuzzyThreshold is the lowest Jaro-Winkler similarity of a
+	// word to be treated as a fuzzy match
+	searchFuzzyThreshold = 0.8
+	// searchSnippetWidth is the count of the runes around the matched
+	// word in a snippet
+	searchSnippetWidth = 32
+)
+
+// helpSearch is the action of `--help-search`, it searches the names,
+// aliases, descriptions, examples and env vars of all commands and
+// flags, and prints the ranked results.
+func helpSearch(cmd *Command, args []string) (err error) {
+	w := internalGetWorker()
+	term := strings.TrimSpace(GetStringR("help-search"))
+	if len(term) == 0 {
+		ferr("%v", T("Nothing to search, try: --help-search TERM"))
+		return ErrShouldBeStopException
+	}
+
+	hits := searchCommands(&w.rootCommand.Command, term)
+	if len(hits) == 0 {
+		fp("%v", T("No matches for %q.", term))
+		return ErrShouldBeStopException
+	}
+
+	_ = Paged(func(out io.Writer) (err error) {
+		fp("%v\n", T("Search results for %q:", term))
+		for _, hit := range hits {
+			title, desc := w.rootCommand.AppName, hit.cmd.GetDescription()
+			if !hit.cmd.IsRoot() {
+				title += " " + replaceAll(w.backtraceCmdNames(hit.cmd), ".", " ")
+			}
+			if hit.flg != nil {
+				title += " " + strings.Join(strings.Fields(hit.flg.GetTitleFlagNames()), " ")
+				desc = hit.flg.GetDescription()
+			}
+			if GetNoColorMode() {
+				fp("  %v  - %v", title, desc)
+				fp("      %v: %v", T(hit.field), hit.snippet)
+			} else {
+				fp("  \x1b[%dm%v\x1b[0m  - \x1b[%dm\x1b[%dm%v\x1b[0m", BgBoldOrBright, title, BgNormal, descColor(), desc)
+				fp("      \x1b[%dm%v:\x1b[0m %v", BgDim, T(hit.field), hit.snippet)
+			}
+		}
+		return
+	})
+	return ErrShouldBeStopException
+}
+
+// searchCommands returns the matched commands and flags under root,
+// ordered by the score.
+func searchCommands(root *Command, term string) (hits []*searchHit) {
+	words := searchWords(term)
+	_ = walkFromCommand(root, 0, func(cmd *Command, index int) (err error) {
+		if cmd.Hidden {
+			return
+		}
+		if !cmd.IsRoot() {
+			if hit := searchMatch(term, words, searchFieldsOf(&cmd.BaseOpt, nil)); hit != nil {
+				hit.cmd = cmd
+				hits = append(hits, hit)
+			}
+		}
+		for _, flg := range cmd.Flags {
+			if flg.Hidden {
+				continue
+			}
+			if hit := searchMatch(term, words, searchFieldsOf(&flg.BaseOpt, flg.EnvVars)); hit != nil {
+				hit.cmd, hit.flg = cmd, flg
+				hits = append(hits, hit)
+			}
+		}
+		return
+	})
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].score > hits[j].score
+	})
+	return
+}
+
+func searchFieldsOf(opt *BaseOpt, envVars []string) (fields []searchField) {
+	names := opt.GetTitleNamesArray()
+	if len(opt.Name) > 0 {
+		names = uniAddStrs(names, opt.Name)
+	}
+	var examples string
+	if len(opt.Examples) > 0 {
+		examples = tplApply(opt.GetExamples(), internalGetWorker().rootCommand)
+	}
+	fields = append(fields,
+		searchField{"name", strings.Join(names, " "), 1.0},
+		searchField{"description", opt.GetDescription(), 0.8},
+		searchField{"long description", opt.GetLongDescription(), 0.7},
+		searchField{"examples", examples, 0.6},
+	)
+	if len(envVars) > 0 {
+		fields = append(fields, searchField{"env", strings.Join(envVars, " "), 0.9})
+	}
+	return
+}
+
+// searchMatch finds the best matched field. A name equal to the term
+// ranks first, a field containing the whole term scores its weight,
+// otherwise the words of term will be compared with the words of
+// field by Jaro-Winkler distance.
+func searchMatch(term string, words []string, fields []searchField) (hit *searchHit) {
+	lowerTerm := strings.ToLower(term)
+	for _, f := range fields {
+		if len(strings.TrimSpace(f.text)) == 0 {
+			continue
+		}
+
+		var score float64
+		var start, end int
+		if ix := searchIndexFold(f.text, lowerTerm); ix >= 0 {
+			score, start, end = f.weight, ix, ix+len(lowerTerm)
+			if f.name == "name" && searchIsName(f.text, lowerTerm) {
+				score += 0.5
+			}
+		} else {
+			var sim float64
+			if sim, start, end = searchFuzzy(words, f.text); sim < searchFuzzyThreshold {
+				continue
+			}
+			score = sim * f.weight * 0.9
+		}
+
+		if hit == nil || score > hit.score {
+			hit = &searchHit{score: score, field: f.name, snippet: searchSnippet(f.text, start, end)}
+		}
+	}
+	return
+}
+
+// searchFuzzy returns the average similarity of the words of term,
+// with the position of the best matched word in text.
+func searchFuzzy(words []string, text string) (sim float64, start, end int) {
+	tokens := searchTokens(text)
+	best := -1.0
+	for _, w := range words {
+		var max float64
+		var ts, te int
+		for _, t := range tokens {
+			d := float64(defaultStringMetric.Calc(w, strings.ToLower(text[t[0]:t[1]]))) / stringMetricFactor
+			if d > max {
+				max, ts, te = d, t[0], t[1]
+			}
+		}
+		sim += max
+		if max > best {
+			best, start, end = max, ts, te
+		}
+	}
+	if len(words) > 0 {
+		sim /= float64(len(words))
+	}
+	return
+}
+
+// searchIndexFold returns the byte index of the lower-cased term in
+// text, case-insensitively.
+func searchIndexFold(text, lowerTerm string) int {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// the offsets cannot be mapped back to text
+		return strings.Index(text, lowerTerm)
+	}
+	return strings.Index(lower, lowerTerm)
+}
+
+func searchIsName(names, lowerTerm string) bool {
+	for _, n := range strings.Fields(names) {
+		if strings.ToLower(n) == lowerTerm {
+			return true
+		}
+	}
+	return false
+}
+
+func searchWords(term string) []string {
+	return strings.FieldsFunc(strings.ToLower(term), searchIsDelimiter)
+}
+
+// searchTokens returns the byte ranges of the words in text.
+func searchTokens(text string) (tokens [][2]int) {
+	start := -1
+	for i, r := range text {
+		if searchIsDelimiter(r) {
+			if start >= 0 {
+				tokens = append(tokens, [2]int{start, i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, [2]int{start, len(text)})
+	}
+	return
+}
+
+func searchIsDelimiter(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+}
+
+// searchSnippet cuts a single line around text[start:end] and
+// highlights the matched part.
+func searchSnippet(text string, start, end int) string {
+	before, matched, after := []rune(text[:start]), text[start:end], []rune(text[end:])
+
+	prefix, suffix := "", ""
+	if len(before) > searchSnippetWidth {
+		before, prefix = before[len(before)-searchSnippetWidth:], "..."
+	}
+	if len(after) > searchSnippetWidth {
+		after, suffix = after[:searchSnippetWidth], "..."
+	}
+
+	oneLine := func(s string) string { return strings.Join(strings.Fields(s), " ") }
+	b, a := oneLine(string(before)), oneLine(string(after))
+	if len(b) > 0 && unicode.IsSpace(before[len(before)-1]) {
+		b += " "
+	}
+	if len(a) > 0 && unicode.IsSpace(after[0]) {
+		a = " " + a
+	}
+
+	if GetNoColorMode() {
+		matched = fmt.Sprintf("*%v*", matched)
+	} else {
+		matched = fmt.Sprintf("\x1b[%dm\x1b[%dm%v\x1b[0m", BgBoldOrBright, highlightColor(), matched)
+	}
+	return prefix + b + matched + a + suffix
+}
@@ -0,0 +1,56 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestHelpSearch(t *testing.T) {
+	defer resetOsArgs()
+
+	root := cmdr.Root("search-app", "1.0.0")
+	server := root.NewSubCommand("server", "s").
+		Description("server operations", "start, stop or restart the http server")
+	server.NewSubCommand("start").
+		Description("start the server", "").
+		Examples("$ {{.AppName}} server start --port 8080")
+	server.NewSubCommand("stop").Description("stop the server", "")
+	server.NewFlagV(8080, "port", "p").
+		Description("the listening port", "").
+		EnvKeys("HTTP_PORT")
+	root.NewSubCommand("deploy").Description("deploy the services", "")
+
+	for _, c := range []struct {
+		term    string
+		expects []string
+	}{
+		{"start", []string{"search-app server start  - start the server", "name: *start*"}},
+		{"servre", []string{"search-app server  - server operations", "name: s *server*"}},
+		{"http_port", []string{"search-app server -p, --port  - the listening port", "env: *HTTP_PORT*"}},
+		{"8080", []string{"examples: ...search-app server start --port *8080*"}},
+		{"zzzzzz", []string{"No matches for \"zzzzzz\"."}},
+	} {
+		cmdr.ResetOptions()
+		cmdr.InternalResetWorker()
+		outX, _ := prepareStreams()
+
+		os.Args = []string{"search-app", "--no-color", "--help-search", c.term}
+		if err := cmdr.Exec(root.RootCommand(), cmdr.WithNoLoadConfigFiles(true)); err != nil {
+			t.Fatal(err)
+		}
+		cmdr.InternalResetWorker() // flush the outputs
+
+		out := outX.String()
+		for _, s := range c.expects {
+			if !strings.Contains(out, s) {
+				t.Fatalf("%q: expecting %q in the results:\n%v", c.term, s, out)
+			}
+		}
+	}
+	cmdr.ResetOptions()
+}
@@ -0,0 +1,185 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestHelpFlagBindings(t *testing.T) {
+	defer resetOsArgs()
+	defer os.Unsetenv("HTTP_PORT")
+
+	root := cmdr.Root("bindings-app", "1.0.0")
+	server := root.NewSubCommand("server", "s").Description("server operations", "")
+	server.NewFlagV(8080, "port", "p").
+		Description("the listening port", "").
+		EnvKeys("HTTP_PORT")
+
+	for _, c := range []struct {
+		args   []string
+		env    string
+		expect string
+	}{
+		{[]string{"bindings-app", "server", "--help", "--help-bindings"}, "",
+			"env: HTTP_PORT, CMDR_APP_SERVER_PORT; config: app.server.port; value: 8080 (default)"},
+		{[]string{"bindings-app", "server", "--help", "--help-bindings"}, "9090",
+			"config: app.server.port; value: 9090 (env HTTP_PORT)"},
+		{[]string{"bindings-app", "server", "--port", "7070", "--help", "--help-bindings"}, "",
+			"config: app.server.port; value: 7070 (flag --port)"},
+	} {
+		cmdr.ResetOptions()
+		cmdr.InternalResetWorker()
+		outX, _ := prepareStreams()
+
+		if len(c.env) > 0 {
+			_ = os.Setenv("HTTP_PORT", c.env)
+		} else {
+			_ = os.Unsetenv("HTTP_PORT")
+		}
+		os.Args = c.args
+		if err := cmdr.Exec(root.RootCommand(), cmdr.WithNoLoadConfigFiles(true), cmdr.WithNoColor(true)); err != nil {
+			t.Fatal(err)
+		}
+		cmdr.InternalResetWorker() // flush the outputs
+
+		if out := outX.String(); !strings.Contains(out, c.expect) {
+			t.Fatalf("%v: expecting %q in help screen:\n%v", c.args, c.expect, out)
+		}
+	}
+
+	// a dotenv value equal to the default is still from the dotenv file
+	dir, err := ioutil.TempDir("", "cmdr-bindings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	envFile := path.Join(dir, "app.env")
+	_ = ioutil.WriteFile(envFile, []byte("APP_SERVER_PORT=8080\n"), 0644)
+	_ = os.Unsetenv("HTTP_PORT")
+
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+	outX, _ := prepareStreams()
+	os.Args = []string{"bindings-app", "server", "--env-file", envFile, "--help", "--help-bindings"}
+	if err = cmdr.Exec(root.RootCommand(), cmdr.WithPredefinedLocations(dir+"/%s.yml"), cmdr.WithNoWatchConfigFiles(true), cmdr.WithNoColor(true)); err != nil {
+		t.Fatal(err)
+	}
+	expect := fmt.Sprintf("value: 8080 (dotenv %v:1 APP_SERVER_PORT)", envFile)
+	if src, ok := cmdr.GetSource("app.server.port"); !ok || !strings.HasSuffix(expect, "("+src.String()+")") {
+		t.Fatalf("the source %q differs from the help screen %q", src, expect)
+	}
+	cmdr.InternalResetWorker() // flush the outputs
+
+	if out := outX.String(); !strings.Contains(out, expect) {
+		t.Fatalf("expecting %q in help screen:\n%v", expect, out)
+	}
+	cmdr.ResetOptions()
+}
+
+func TestPaged(t *testing.T) {
+	defer resetOsArgs()
+
+	root := cmdr.Root("paged-app", "1.0.0")
+	root.NewSubCommand("list", "ls").
+		Description("list the items", "").
+		Action(func(cmd *cmdr.Command, args []string) (err error) {
+			return cmdr.Paged(func(out io.Writer) (err error) {
+				for i := 0; i < 3; i++ {
+					_, _ = fmt.Fprintf(out, "item %d\n", i)
+				}
+				// the nested one writes to the outer pager
+				return cmdr.Paged(func(out io.Writer) (err error) {
+					_, err = fmt.Fprintln(out, "done")
+					return
+				})
+			})
+		})
+
+	cmdr.ResetOptions()
+	cmdr.InternalResetWorker()
+	outX, _ := prepareStreams()
+
+	os.Args = []string{"paged-app", "list"}
+	if err := cmdr.Exec(root.RootCommand(), cmdr.WithNoLoadConfigFiles(true)); err != nil {
+		t.Fatal(err)
+	}
+	cmdr.InternalResetWorker() // flush the outputs
+
+	if out := outX.String(); out != "item 0\nitem 1\nitem 2\ndone\n" {
+		t.Fatalf("bad paged output: %q", out)
+	}
+	cmdr.ResetOptions()
+}
+
+func TestTreeFormats(t *testing.T) {
+	defer resetOsArgs()
+
+	// the bool flags keep the parsed values as their default values,
+	// so a new tree is built for each case
+	buildRoot := func() *cmdr.RootCommand {
+		root := cmdr.Root("tree-app", "1.0.0")
+		server := root.NewSubCommand("server", "s").Description("server operations", "").Group("Ops")
+		server.NewSubCommand("start").Description("start the server", "")
+		server.NewSubCommand("stop").Description("stop the server", "").Deprecated("0.9.0")
+		server.NewFlagV(8080, "port", "p").Description("the listening port", "")
+		root.NewSubCommand("deploy").Description("deploy the services", "").Group("Dev")
+		root.NewSubCommand("secret").Hidden(true)
+		return root.RootCommand()
+	}
+
+	for _, c := range []struct {
+		args     []string
+		expects  []string
+		excludes []string
+	}{
+		{[]string{"tree-app", "--no-color", "--tree"},
+			[]string{"ROOT\n  s, server - server operations\n    start - start the server\n    stop - stop the server [deprecated since 0.9.0]\n  deploy - deploy the services"},
+			[]string{"secret", "--port"}},
+		{[]string{"tree-app", "--no-color", "server", "--tree", "--tree-flags"},
+			[]string{"s, server - server operations\n  -p, --port - the listening port\n  start - start the server"},
+			[]string{"ROOT", "deploy"}},
+		{[]string{"tree-app", "--tree", "--tree-hidden", "--tree-group", "ops", "--tree-format", "json"},
+			[]string{`"titles": [
+    "tree-app"
+  ]`, `"deprecated": "0.9.0"`, `"group": "Ops"`},
+			[]string{"deploy", "secret"}},
+		{[]string{"tree-app", "--tree", "--tree-hidden", "--tree-format=dot"},
+			[]string{`digraph "tree-app" {`, `n1 [label="s, server\nserver operations"];`, `[label="secret [hidden]", style=dashed`, "n0 -> n1;"},
+			nil},
+		{[]string{"tree-app", "--tree", "--tree-flags", "--tree-format", "mermaid"},
+			[]string{"graph LR", `n5 -.- n6(["-p, --port<br/>the listening port"])`, `n0 --> n5["s, server<br/>server operations"]`, "class n8 deprecated"},
+			nil},
+	} {
+		cmdr.ResetOptions()
+		cmdr.InternalResetWorker()
+		outX, _ := prepareStreams()
+
+		os.Args = c.args
+		if err := cmdr.Exec(buildRoot(), cmdr.WithNoLoadConfigFiles(true)); err != nil {
+			t.Fatal(err)
+		}
+		cmdr.InternalResetWorker() // flush the outputs
+
+		out := outX.String()
+		for _, s := range c.expects {
+			if !strings.Contains(out, s) {
+				t.Fatalf("%v: expecting %q in the tree:\n%v", c.args, s, out)
+			}
+		}
+		for _, s := range c.excludes {
+			if strings.Contains(out, s) {
+				t.Fatalf("%v: not expecting %q in the tree:\n%v", c.args, s, out)
+			}
+		}
+	}
+	cmdr.ResetOptions()
+}
@@ -5,45 +5,270 @@
 package cmdr
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 )
 
-func dumpTreeForAllCommands(cmd *Command, args []string) (err error) {
-	command := &internalGetWorker().rootCommand.Command
-	_ = walkFromCommand(command, 0, func(cmd *Command, index int) (e error) {
-		if cmd.Hidden {
-			return
-		}
+type (
+	// treeNode is a command or a flag in the outputs of `--tree`
+	treeNode struct {
+		Titles      []string    `json:"titles"`
+		Description string      `json:"description,omitempty"`
+		Group       string      `json:"group,omitempty"`
+		Hidden      bool        `json:"hidden,omitempty"`
+		Deprecated  string      `json:"deprecated,omitempty"`
+		RemovedIn   string      `json:"removed-in,omitempty"`
+		Default     interface{} `json:"default,omitempty"`
+		Flags       []*treeNode `json:"flags,omitempty"`
+		Commands    []*treeNode `json:"commands,omitempty"`
 
-		deep := findDepth(cmd) - 1
-		if deep == 0 {
-			fmt.Println("ROOT")
-		} else {
-			sp := strings.Repeat("  ", deep)
-			// fmt.Printf("%s%v - \x1b[%dm\x1b[%dm%s\x1b[0m\n",
-			// 	sp, cmd.GetTitleNames(),
-			// 	BgNormal, CurrentDescColor, cmd.Description)
-
-			if len(cmd.Deprecated) > 0 {
-				if GetNoColorMode() {
-					fmt.Printf("%s%s - %s [deprecated since %v]\n",
-						sp, cmd.GetTitleNames(), cmd.Description, cmd.Deprecated)
-				} else {
-					fmt.Printf("%s\x1b[%dm\x1b[%dm%s - %s\x1b[0m [deprecated since %v]\n",
-						sp, BgNormal, CurrentDescColor, cmd.GetTitleNames(), cmd.Description,
-						cmd.Deprecated)
-				}
-			} else {
-				if GetNoColorMode() {
-					fmt.Printf("%s%s - %s\n", sp, cmd.GetTitleNames(), cmd.Description)
-				} else {
-					fmt.Printf("%s%s - \x1b[%dm\x1b[%dm%s\x1b[0m\n",
-						sp, cmd.GetTitleNames(), BgNormal, CurrentDescColor, cmd.Description)
-				}
+		isFlag bool
+		id     int
+	}
+
+	// treeOptions holds the values of `--tree-*` flags
+	treeOptions struct {
+		flags  bool
+		hidden bool
+		group  string
+	}
+)
+
+// requestTree is the action of `--tree`, the tree will be printed by
+// dumpTree after all flags parsed.
+func requestTree(cmd *Command, args []string) (err error) {
+	internalGetWorker().treeRequested = true
+	return
+}
+
+// dumpTree prints the command tree from cmd, in the format specified by
+// `--tree-format`: text, json, dot (Graphviz) or mermaid.
+//
+//     app --tree                                   # the whole tree
+//     app server --tree --tree-flags               # the subtree, with flags
+//     app --tree --tree-format dot | dot -Tsvg > cli.svg
+//     app --tree --tree-format mermaid --tree-group Misc --tree-hidden
+func dumpTree(cmd *Command) (err error) {
+	opts := &treeOptions{
+		flags:  GetBoolR("tree-flags"),
+		hidden: GetBoolR("tree-hidden"),
+		group:  GetStringR("tree-group"),
+	}
+	root := opts.build(cmd, len(opts.group) == 0)
+	if root == nil {
+		// nothing matched the group, keep the subtree root at least
+		root = opts.node(cmd)
+	}
+	if cmd.IsRoot() {
+		root.Titles = []string{internalGetWorker().rootCommand.AppName}
+	}
+
+	return Paged(func(out io.Writer) (err error) {
+		switch format := strings.ToLower(GetStringR("tree-format")); format {
+		case "json":
+			var b []byte
+			if b, err = json.MarshalIndent(root, "", "  "); err == nil {
+				_, err = fmt.Fprintf(out, "%s\n", b)
 			}
+		case "dot":
+			dumpTreeDot(out, root)
+		case "mermaid":
+			dumpTreeMermaid(out, root)
+		default:
+			dumpTreeText(out, root, 0, cmd.IsRoot())
 		}
 		return
 	})
-	return ErrShouldBeStopException
+}
+
+// build returns the node of cmd and its descendants, or nil if cmd is
+// filtered out. A command matches the group filter if its group or
+// the group of an ancestor equals to the filter, or a descendant
+// matches; the unmatched siblings are dropped.
+func (s *treeOptions) build(cmd *Command, matched bool) (node *treeNode) {
+	if !matched && strings.EqualFold(StripOrderPrefix(cmd.Group), s.group) {
+		matched = true
+	}
+
+	node = s.node(cmd)
+	for _, cx := range cmd.SubCommands {
+		if cx.Hidden && !s.hidden {
+			continue
+		}
+		if child := s.build(cx, matched); child != nil {
+			node.Commands = append(node.Commands, child)
+		}
+	}
+	if !matched && len(node.Commands) == 0 {
+		return nil
+	}
+	return
+}
+
+func (s *treeOptions) node(cmd *Command) (node *treeNode) {
+	node = &treeNode{
+		Titles:      cmd.GetTitleNamesArray(),
+		Description: cmd.GetDescription(),
+		Group:       StripOrderPrefix(cmd.Group),
+		Hidden:      cmd.Hidden,
+		Deprecated:  cmd.Deprecated,
+		RemovedIn:   cmd.RemovedIn,
+	}
+	if node.Group == StripOrderPrefix(UnsortedGroup) {
+		node.Group = ""
+	}
+	if s.flags {
+		for _, flg := range cmd.Flags {
+			if flg.Hidden && !s.hidden {
+				continue
+			}
+			node.Flags = append(node.Flags, &treeNode{
+				Titles:      treeFlagTitles(flg),
+				Description: flg.GetDescription(),
+				Hidden:      flg.Hidden,
+				Deprecated:  flg.Deprecated,
+				RemovedIn:   flg.RemovedIn,
+				Default:     flg.DefaultValue,
+				isFlag:      true,
+			})
+		}
+	}
+	return
+}
+
+func treeFlagTitles(flg *Flag) (titles []string) {
+	if len(flg.Short) > 0 {
+		titles = append(titles, "-"+flg.Short)
+	}
+	for _, n := range flg.GetLongTitleNamesArray() {
+		titles = append(titles, "--"+n)
+	}
+	return
+}
+
+func (s *treeNode) title() string {
+	return strings.Join(s.Titles, ", ")
+}
+
+func (s *treeNode) marker() (marker string) {
+	if len(s.Deprecated) > 0 {
+		marker = " [" + T("deprecated since %v", s.Deprecated) + "]"
+	}
+	if s.Hidden {
+		marker += " [" + T("hidden") + "]"
+	}
+	return
+}
+
+func dumpTreeText(out io.Writer, node *treeNode, deep int, isRoot bool) {
+	sp := strings.Repeat("  ", deep)
+	switch {
+	case deep == 0 && isRoot:
+		_, _ = fmt.Fprintln(out, "ROOT")
+	case GetNoColorMode():
+		_, _ = fmt.Fprintf(out, "%s%s - %s%s\n", sp, node.title(), node.Description, node.marker())
+	case len(node.Deprecated) > 0:
+		_, _ = fmt.Fprintf(out, "%s\x1b[%dm\x1b[%dm%s - %s\x1b[0m%s\n",
+			sp, BgNormal, descColor(), node.title(), node.Description, node.marker())
+	default:
+		_, _ = fmt.Fprintf(out, "%s%s - \x1b[%dm\x1b[%dm%s\x1b[0m%s\n",
+			sp, node.title(), BgNormal, descColor(), node.Description, node.marker())
+	}
+
+	for _, flg := range node.Flags {
+		dumpTreeText(out, flg, deep+1, false)
+	}
+	for _, cx := range node.Commands {
+		dumpTreeText(out, cx, deep+1, false)
+	}
+}
+
+// walkTree numbers the nodes and calls fn for each node with its
+// parent, the parent of the root node is nil.
+func walkTree(node *treeNode, fn func(parent, node *treeNode)) {
+	id := 0
+	var walk func(parent, node *treeNode)
+	walk = func(parent, node *treeNode) {
+		node.id, id = id, id+1
+		fn(parent, node)
+		for _, flg := range node.Flags {
+			walk(node, flg)
+		}
+		for _, cx := range node.Commands {
+			walk(node, cx)
+		}
+	}
+	walk(nil, node)
+}
+
+func dumpTreeDot(out io.Writer, root *treeNode) {
+	_, _ = fmt.Fprintf(out, "digraph %q {\n  rankdir=LR;\n  node [shape=box, fontname=\"Helvetica\"];\n", root.title())
+	walkTree(root, func(parent, node *treeNode) {
+		label := node.title()
+		if len(node.Description) > 0 {
+			label += "\n" + node.Description
+		}
+		label += node.marker()
+
+		var attrs []string
+		if node.isFlag {
+			attrs = append(attrs, "shape=ellipse")
+		}
+		if len(node.Deprecated) > 0 || node.Hidden {
+			attrs = append(attrs, "style=dashed", "color=gray", "fontcolor=gray")
+		}
+		_, _ = fmt.Fprintf(out, "  n%d [label=%q%s];\n", node.id, label, dotAttrs(attrs))
+		if parent != nil {
+			style := ""
+			if node.isFlag {
+				style = " [style=dashed, arrowhead=none]"
+			}
+			_, _ = fmt.Fprintf(out, "  n%d -> n%d%s;\n", parent.id, node.id, style)
+		}
+	})
+	_, _ = fmt.Fprintln(out, "}")
+}
+
+func dotAttrs(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(attrs, ", ")
+}
+
+func dumpTreeMermaid(out io.Writer, root *treeNode) {
+	_, _ = fmt.Fprintln(out, "graph LR")
+	var deprecated []string
+	walkTree(root, func(parent, node *treeNode) {
+		label := node.title()
+		if len(node.Description) > 0 {
+			label += "<br/>" + node.Description
+		}
+		label = mermaidEscape(label + node.marker())
+
+		shape := fmt.Sprintf("n%d[\"%s\"]", node.id, label)
+		if node.isFlag {
+			shape = fmt.Sprintf("n%d([\"%s\"])", node.id, label)
+		}
+		if parent == nil {
+			_, _ = fmt.Fprintf(out, "  %s\n", shape)
+		} else if node.isFlag {
+			_, _ = fmt.Fprintf(out, "  n%d -.- %s\n", parent.id, shape)
+		} else {
+			_, _ = fmt.Fprintf(out, "  n%d --> %s\n", parent.id, shape)
+		}
+		if len(node.Deprecated) > 0 || node.Hidden {
+			deprecated = append(deprecated, fmt.Sprintf("n%d", node.id))
+		}
+	})
+	if len(deprecated) > 0 {
+		_, _ = fmt.Fprintln(out, "  classDef deprecated stroke-dasharray: 5 5, color: gray")
+		_, _ = fmt.Fprintf(out, "  class %s deprecated\n", strings.Join(deprecated, ","))
+	}
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", "<br/>").Replace(s)
 }
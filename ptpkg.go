@@ -47,13 +47,14 @@ func (pkg *ptpkg) tryToggleGroup() {
 	tg := pkg.flg.ToggleGroup
 	if len(tg) > 0 {
 		wkr := internalGetWorker()
+		src := pkg.source()
 		for _, f := range pkg.flg.owner.Flags {
 			if f.ToggleGroup == tg && (isBool(f.DefaultValue) || isNil1(f.DefaultValue)) {
 				if f != pkg.flg {
-					wkr.rxxtOptions.Set(wkr.backtraceFlagNames(f), false)
+					wkr.rxxtOptions.setFrom(wkr.backtraceFlagNames(f), false, src)
 					f.DefaultValue = false
 				} else {
-					wkr.rxxtOptions.Set(wkr.backtraceFlagNames(f), true)
+					wkr.rxxtOptions.setFrom(wkr.backtraceFlagNames(f), true, src)
 					f.DefaultValue = true
 				}
 			}
@@ -236,9 +237,9 @@ func (pkg *ptpkg) processExternalTool() (err error) {
 
 func (pkg *ptpkg) xxSet(keyPath string, v interface{}) {
 	if pkg.a[0] == '~' {
-		internalGetWorker().rxxtOptions.SetNx(keyPath, v)
+		internalGetWorker().rxxtOptions.setNxFrom(keyPath, v, pkg.source())
 	} else {
-		internalGetWorker().rxxtOptions.Set(keyPath, v)
+		internalGetWorker().rxxtOptions.setFrom(keyPath, v, pkg.source())
 	}
 	if pkg.flg != nil && pkg.flg.onSet != nil {
 		pkg.flg.onSet(keyPath, v)
@@ -341,6 +342,10 @@ func (pkg *ptpkg) processTypeString(args []string) (err error) {
 				}
 			}
 			pkg.found = true
+			if wkr.dryRun {
+				wkr.dryRunIssues = append(wkr.dryRunIssues, T("unexpected value %q for %v, expecting one of %v",
+					pkg.val, pkg.flg.GetTitleZshFlagName(), pkg.flg.ValidArgs))
+			}
 			err = newError(wkr.shouldIgnoreWrongEnumValue,
 				errWrongEnumValue, // .Format(pkg.val, pkg.fn, pkg.flg.owner.GetName()),
 				pkg.val, pkg.flg.GetTitleZshFlagName(), pkg.flg.owner.GetName(),
@@ -413,3 +418,20 @@ func (pkg *ptpkg) processTypeUintSlice(args []string) (err error) {
 	}
 	return
 }
+
+// source returns the source of the values set by the current flag,
+// such as `--port`.
+func (pkg *ptpkg) source() *ValueSource {
+	name := pkg.a
+	if ix := strings.Index(name, "="); ix > 0 {
+		name = name[:ix]
+	}
+	if pkg.flg != nil {
+		if pkg.short || len(pkg.flg.Full) == 0 {
+			name = "-" + pkg.flg.Short
+		} else {
+			name = "--" + pkg.flg.Full
+		}
+	}
+	return &ValueSource{Layer: SourceFlag, Name: name}
+}
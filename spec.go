@@ -0,0 +1,476 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/hedzr/errors.v2"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// RootSpec is the declarative form of a RootCommand, it can be
+	// loaded from a yaml or json file by LoadRootCommandFromFile.
+	//
+	// A minimal spec file looks like:
+	//
+	//     app-name: austr
+	//     version: 1.0.1
+	//     sub-commands:
+	//       - full: deploy
+	//         short: d
+	//         description: deploy the services
+	//         action: deploy        # bound by cmdr.RegisterAction("deploy", fn)
+	//         flags:
+	//           - full: dry-run
+	//             short: n
+	//             default: false
+	//
+	RootSpec struct {
+		AppName   string `yaml:"app-name,omitempty" json:"app-name,omitempty"`
+		Version   string `yaml:"version,omitempty" json:"version,omitempty"`
+		Copyright string `yaml:"copyright,omitempty" json:"copyright,omitempty"`
+		Author    string `yaml:"author,omitempty" json:"author,omitempty"`
+		Header    string `yaml:"header,omitempty" json:"header,omitempty"`
+
+		// PostActions are the names of registered post-actions, see RegisterPostAction
+		PostActions []string `yaml:"post-actions,omitempty" json:"post-actions,omitempty"`
+
+		// Translations are the message tables keyed by locale, see AddTranslations
+		Translations map[string]map[string]string `yaml:"translations,omitempty" json:"translations,omitempty"`
+
+		CommandSpec `yaml:",inline"`
+	}
+
+	// CommandSpec is the declarative form of a Command
+	CommandSpec struct {
+		BaseOptSpec `yaml:",inline"`
+
+		// PreAction is the name of a registered action, see RegisterAction
+		PreAction string `yaml:"pre-action,omitempty" json:"pre-action,omitempty"`
+		// PostAction is the name of a registered post-action, see RegisterPostAction
+		PostAction      string `yaml:"post-action,omitempty" json:"post-action,omitempty"`
+		TailPlaceHolder string `yaml:"tail-placeholder,omitempty" json:"tail-placeholder,omitempty"`
+
+		Flags       []*FlagSpec    `yaml:"flags,omitempty" json:"flags,omitempty"`
+		SubCommands []*CommandSpec `yaml:"sub-commands,omitempty" json:"sub-commands,omitempty"`
+	}
+
+	// FlagSpec is the declarative form of a Flag
+	FlagSpec struct {
+		BaseOptSpec `yaml:",inline"`
+
+		// Type is the value type of this flag, such as: bool, int, uint,
+		// int64, uint64, float32, float64, complex128, string, duration,
+		// []string, []int, []int64, []uint64.
+		// If it's empty, the type will be inferred from Default.
+		Type        string      `yaml:"type,omitempty" json:"type,omitempty"`
+		Default     interface{} `yaml:"default,omitempty" json:"default,omitempty"`
+		Placeholder string      `yaml:"placeholder,omitempty" json:"placeholder,omitempty"`
+		ToggleGroup string      `yaml:"toggle-group,omitempty" json:"toggle-group,omitempty"`
+		ValidArgs   []string    `yaml:"valid-args,omitempty" json:"valid-args,omitempty"`
+		Required    bool        `yaml:"required,omitempty" json:"required,omitempty"`
+
+		ExternalTool string   `yaml:"external-tool,omitempty" json:"external-tool,omitempty"`
+		EnvVars      []string `yaml:"env-vars,omitempty" json:"env-vars,omitempty"`
+		// ReplacedBy is the long name of a flag in the same command,
+		// this flag will be forwarded to it.
+		ReplacedBy string `yaml:"replaced-by,omitempty" json:"replaced-by,omitempty"`
+
+		HeadLike bool  `yaml:"head-like,omitempty" json:"head-like,omitempty"`
+		Min      int64 `yaml:"min,omitempty" json:"min,omitempty"`
+		Max      int64 `yaml:"max,omitempty" json:"max,omitempty"`
+	}
+
+	// BaseOptSpec is the declarative form of BaseOpt
+	BaseOptSpec struct {
+		Name    string   `yaml:"name,omitempty" json:"name,omitempty"`
+		Short   string   `yaml:"short,omitempty" json:"short,omitempty"`
+		Full    string   `yaml:"full,omitempty" json:"full,omitempty"`
+		Aliases []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+		Group   string   `yaml:"group,omitempty" json:"group,omitempty"`
+
+		Description     string `yaml:"description,omitempty" json:"description,omitempty"`
+		LongDescription string `yaml:"long-description,omitempty" json:"long-description,omitempty"`
+		Examples        string `yaml:"examples,omitempty" json:"examples,omitempty"`
+		Hidden          bool   `yaml:"hidden,omitempty" json:"hidden,omitempty"`
+		Deprecated      string `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
+		RemovedIn       string `yaml:"removed-in,omitempty" json:"removed-in,omitempty"`
+
+		// Action is the name of a registered action, see RegisterAction
+		Action string `yaml:"action,omitempty" json:"action,omitempty"`
+	}
+)
+
+var (
+	specActions     = make(map[string]func(cmd *Command, args []string) (err error))
+	specPostActions = make(map[string]func(cmd *Command, args []string))
+	specRW          sync.RWMutex
+)
+
+// RegisterAction binds an action function to a name, so that it
+// can be referenced by the `action` and `pre-action` fields of
+// a command spec file.
+//
+//     cmdr.RegisterAction("deploy", func(cmd *cmdr.Command, args []string) (err error) {
+//         return
+//     })
+//     root, err := cmdr.LoadRootCommandFromFile("ci/etc/austr/cli.yml")
+//
+func RegisterAction(name string, action func(cmd *Command, args []string) (err error)) {
+	specRW.Lock()
+	defer specRW.Unlock()
+	specActions[name] = action
+}
+
+// RegisterPostAction binds a post-action function to a name, so that
+// it can be referenced by the `post-action` field of a command spec
+// file, or the `post-actions` list of a root spec.
+func RegisterPostAction(name string, action func(cmd *Command, args []string)) {
+	specRW.Lock()
+	defer specRW.Unlock()
+	specPostActions[name] = action
+}
+
+func findSpecAction(name string) (action func(cmd *Command, args []string) (err error), err error) {
+	if len(name) > 0 {
+		specRW.RLock()
+		defer specRW.RUnlock()
+		var ok bool
+		if action, ok = specActions[name]; !ok {
+			err = errors.New("action '%v' not registered, see also cmdr.RegisterAction", name)
+		}
+	}
+	return
+}
+
+func findSpecPostAction(name string) (action func(cmd *Command, args []string), err error) {
+	if len(name) > 0 {
+		specRW.RLock()
+		defer specRW.RUnlock()
+		var ok bool
+		if action, ok = specPostActions[name]; !ok {
+			err = errors.New("post-action '%v' not registered, see also cmdr.RegisterPostAction", name)
+		}
+	}
+	return
+}
+
+// LoadRootCommandFromFile loads a yaml/json command spec file and
+// builds the RootCommand from it.
+// The file format is detected by its suffix, `.json` for json, and
+// yaml for the others.
+func LoadRootCommandFromFile(file string) (root *RootCommand, err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(file); err != nil {
+		return
+	}
+	if root, err = LoadRootCommandFrom(bytes.NewReader(b), path.Ext(file)); err != nil {
+		err = errors.New("error in loading command spec file '%s': %v", file, err)
+	}
+	return
+}
+
+// LoadRootCommandFrom loads a command spec from a reader and builds
+// the RootCommand from it. `ext` can be ".json" or ".yml"/".yaml".
+func LoadRootCommandFrom(r io.Reader, ext string) (root *RootCommand, err error) {
+	var spec *RootSpec
+	if spec, err = LoadRootSpecFrom(r, ext); err == nil {
+		root, err = spec.ToRootCommand()
+	}
+	return
+}
+
+// LoadRootSpecFrom decodes a command spec from a reader without
+// building it.
+func LoadRootSpecFrom(r io.Reader, ext string) (spec *RootSpec, err error) {
+	var b []byte
+	if b, err = ioutil.ReadAll(r); err != nil {
+		return
+	}
+
+	spec = new(RootSpec)
+	switch ext {
+	case ".json", "json":
+		err = json.Unmarshal(b, spec)
+	default:
+		err = yaml.Unmarshal(b, spec)
+	}
+	return
+}
+
+// ToRootCommand builds a RootCommand from the spec, binding the
+// actions by name from the registry.
+func (s *RootSpec) ToRootCommand() (root *RootCommand, err error) {
+	root = &RootCommand{
+		AppName:   s.AppName,
+		Version:   s.Version,
+		Copyright: s.Copyright,
+		Author:    s.Author,
+		Header:    s.Header,
+	}
+
+	var cmd *Command
+	if cmd, err = s.CommandSpec.ToCommand(); err != nil {
+		return
+	}
+	root.Command = *cmd
+	if len(root.Name) == 0 {
+		root.Name = root.AppName
+	}
+
+	for _, name := range s.PostActions {
+		var fn func(cmd *Command, args []string)
+		if fn, err = findSpecPostAction(name); err != nil {
+			return
+		}
+		root.AppendPostActions(fn)
+	}
+
+	for locale, table := range s.Translations {
+		AddTranslations(locale, table)
+	}
+	return
+}
+
+// ToCommand builds a Command and its sub-commands and flags from the spec.
+func (s *CommandSpec) ToCommand() (cmd *Command, err error) {
+	cmd = &Command{TailPlaceHolder: s.TailPlaceHolder}
+	if err = s.BaseOptSpec.fill(&cmd.BaseOpt); err != nil {
+		return
+	}
+	if cmd.PreAction, err = findSpecAction(s.PreAction); err != nil {
+		return
+	}
+	if cmd.PostAction, err = findSpecPostAction(s.PostAction); err != nil {
+		return
+	}
+
+	for _, fs := range s.Flags {
+		var flg *Flag
+		if flg, err = fs.ToFlag(); err != nil {
+			err = errors.New("flag '%v' of command '%v': %v", fs.Full, s.Full, err)
+			return
+		}
+		cmd.Flags = append(cmd.Flags, flg)
+	}
+	for i, fs := range s.Flags {
+		if len(fs.ReplacedBy) > 0 {
+			if cmd.Flags[i].ReplacedBy = FindFlag(fs.ReplacedBy, cmd); cmd.Flags[i].ReplacedBy == nil {
+				err = errors.New("flag '%v' of command '%v': replaced-by flag '%v' not found", fs.Full, s.Full, fs.ReplacedBy)
+				return
+			}
+		}
+	}
+
+	for _, cs := range s.SubCommands {
+		var sc *Command
+		if sc, err = cs.ToCommand(); err != nil {
+			return
+		}
+		cmd.SubCommands = append(cmd.SubCommands, sc)
+	}
+	return
+}
+
+// ToFlag builds a Flag from the spec.
+func (s *FlagSpec) ToFlag() (flg *Flag, err error) {
+	flg = &Flag{
+		DefaultValuePlaceholder: s.Placeholder,
+		ToggleGroup:             s.ToggleGroup,
+		ValidArgs:               s.ValidArgs,
+		Required:                s.Required,
+		ExternalTool:            s.ExternalTool,
+		EnvVars:                 s.EnvVars,
+		HeadLike:                s.HeadLike,
+		Min:                     s.Min,
+		Max:                     s.Max,
+	}
+	if err = s.BaseOptSpec.fill(&flg.BaseOpt); err == nil {
+		flg.DefaultValue, err = specDefaultValue(s.Type, s.Default)
+	}
+	return
+}
+
+func (s *BaseOptSpec) fill(b *BaseOpt) (err error) {
+	b.Name = s.Name
+	b.Short = s.Short
+	b.Full = s.Full
+	b.Aliases = s.Aliases
+	b.Group = s.Group
+	b.Description = s.Description
+	b.LongDescription = s.LongDescription
+	b.Examples = s.Examples
+	b.Hidden = s.Hidden
+	b.Deprecated = s.Deprecated
+	b.RemovedIn = s.RemovedIn
+	b.Action, err = findSpecAction(s.Action)
+	return
+}
+
+// specDefaultValue converts the decoded default value to the exact
+// type declared by `typ`, since cmdr interprets the value type of an
+// option based on its default value.
+func specDefaultValue(typ string, v interface{}) (ret interface{}, err error) {
+	s := ""
+	if v != nil {
+		s = fmt.Sprint(v)
+	}
+
+	switch strings.ToLower(typ) {
+	case "":
+		ret = specInferDefaultValue(v)
+	case "bool":
+		ret = false
+		if len(s) > 0 {
+			ret, err = strconv.ParseBool(s)
+		}
+	case "int":
+		var i int64
+		i, err = specParseInt(s)
+		ret = int(i)
+	case "int64":
+		ret, err = specParseInt(s)
+	case "uint":
+		var u uint64
+		u, err = specParseUint(s)
+		ret = uint(u)
+	case "uint64":
+		ret, err = specParseUint(s)
+	case "float32":
+		var f float64
+		f, err = specParseFloat(s)
+		ret = float32(f)
+	case "float64", "float":
+		ret, err = specParseFloat(s)
+	case "complex128", "complex":
+		ret = complex128(0)
+		if len(s) > 0 {
+			ret, err = ParseComplexX(s)
+		}
+	case "string":
+		ret = s
+	case "duration":
+		ret = time.Duration(0)
+		if len(s) > 0 {
+			ret, err = time.ParseDuration(s)
+		}
+	case "[]string", "string-slice", "strings":
+		ret = specToStringSlice(v)
+	case "[]int", "int-slice", "ints":
+		ret, err = specToIntSlice(v)
+	case "[]int64", "int64-slice":
+		var a []int
+		if a, err = specToIntSlice(v); err == nil {
+			ret = intSliceToInt64Slice(a)
+		}
+	case "[]uint64", "uint64-slice":
+		var a []int
+		if a, err = specToIntSlice(v); err == nil {
+			ret = intSliceToUint64Slice(a)
+		}
+	default:
+		err = errors.New("unknown flag type '%v'", typ)
+	}
+	return
+}
+
+func specInferDefaultValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case nil:
+		return false
+	case float64:
+		// json decodes all numbers as float64, the whole numbers are int
+		if i, ok := specWholeNumber(vv); ok {
+			return i
+		}
+	case []interface{}:
+		var ints []int
+		for _, it := range vv {
+			if i, ok := it.(int); ok {
+				ints = append(ints, i)
+			} else if f, ok := it.(float64); ok {
+				if i, ok = specWholeNumber(f); !ok {
+					return specToStringSlice(v)
+				}
+				ints = append(ints, i)
+			} else {
+				return specToStringSlice(v)
+			}
+		}
+		return ints
+	}
+	return v
+}
+
+func specWholeNumber(f float64) (i int, ok bool) {
+	i = int(f)
+	ok = float64(i) == f
+	return
+}
+
+func specParseInt(s string) (i int64, err error) {
+	if len(s) == 0 {
+		return
+	}
+	if i, err = strconv.ParseInt(s, 0, 64); err != nil {
+		// json decodes all numbers as float64, such as 1e+06
+		if f, e := strconv.ParseFloat(s, 64); e == nil && f == float64(int64(f)) {
+			i, err = int64(f), nil
+		}
+	}
+	return
+}
+
+func specParseUint(s string) (uint64, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseUint(s, 0, 64)
+}
+
+func specParseFloat(s string) (float64, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func specToStringSlice(v interface{}) (ret []string) {
+	ret = []string{}
+	switch vv := v.(type) {
+	case nil:
+	case []interface{}:
+		for _, it := range vv {
+			ret = append(ret, fmt.Sprint(it))
+		}
+	case string:
+		if len(vv) > 0 {
+			ret = strings.Split(vv, ",")
+		}
+	default:
+		ret = append(ret, fmt.Sprint(vv))
+	}
+	return
+}
+
+func specToIntSlice(v interface{}) (ret []int, err error) {
+	ret = []int{}
+	for _, s := range specToStringSlice(v) {
+		var i int64
+		if i, err = specParseInt(strings.TrimSpace(s)); err != nil {
+			return
+		}
+		ret = append(ret, int(i))
+	}
+	return
+}
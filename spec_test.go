@@ -0,0 +1,194 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"github.com/hedzr/cmdr"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+const specForTesting = `
+app-name: austr
+version: 1.0.1
+copyright: austr is an effective devops tool
+author: hedzr
+sub-commands:
+  - full: deploy
+    short: d
+    aliases: [dep]
+    description: deploy the services
+    action: spec-deploy
+    flags:
+      - full: dry-run
+        short: n
+        default: false
+      - full: port
+        short: p
+        type: int
+        default: 8080
+      - full: listen
+        type: int
+        default: 8080
+        deprecated: 1.0.0
+        removed-in: 2.0.0
+        replaced-by: port
+      - full: timeout
+        type: duration
+        default: 5s
+      - full: tags
+        default: [a, b]
+      - full: level
+        default: info
+        valid-args: [debug, info, warn]
+        required: true
+    sub-commands:
+      - full: status
+        description: show the deployment status
+`
+
+func TestLoadRootCommandFromSpec(t *testing.T) {
+	var deployed bool
+	cmdr.RegisterAction("spec-deploy", func(cmd *cmdr.Command, args []string) (err error) {
+		deployed = true
+		return
+	})
+
+	root, err := cmdr.LoadRootCommandFrom(strings.NewReader(specForTesting), ".yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.AppName != "austr" || root.Version != "1.0.1" || len(root.SubCommands) != 1 {
+		t.Fatalf("bad root: %+v", root)
+	}
+
+	deploy := root.FindSubCommand("deploy")
+	if deploy == nil || deploy.Action == nil || deploy.FindSubCommand("status") == nil {
+		t.Fatalf("bad deploy command: %+v", deploy)
+	}
+	if v, ok := deploy.FindFlag("port").DefaultValue.(int); !ok || v != 8080 {
+		t.Fatalf("bad port default value: %v", deploy.FindFlag("port").DefaultValue)
+	}
+	if v, ok := deploy.FindFlag("timeout").DefaultValue.(time.Duration); !ok || v != 5*time.Second {
+		t.Fatalf("bad timeout default value: %v", deploy.FindFlag("timeout").DefaultValue)
+	}
+	if v, ok := deploy.FindFlag("tags").DefaultValue.([]string); !ok || len(v) != 2 {
+		t.Fatalf("bad tags default value: %v", deploy.FindFlag("tags").DefaultValue)
+	}
+	if listen := deploy.FindFlag("listen"); listen.ReplacedBy != deploy.FindFlag("port") || listen.RemovedIn != "2.0.0" {
+		t.Fatalf("bad listen flag: %+v", listen)
+	}
+	if _, ok := deploy.FindFlag("dry-run").DefaultValue.(bool); !ok {
+		t.Fatalf("bad dry-run default value: %v", deploy.FindFlag("dry-run").DefaultValue)
+	}
+
+	cmdr.InternalResetWorker()
+	cmdr.ResetOptions()
+	defer resetOsArgs()
+	os.Args = []string{"austr", "dep", "-p", "9000", "-n"}
+	if err = cmdr.Exec(root, cmdr.WithNoLoadConfigFiles(true), cmdr.WithInternalOutputStreams(nil, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if !deployed || cmdr.GetIntR("deploy.port") != 9000 || !cmdr.GetBoolR("deploy.dry-run") {
+		t.Fatalf("the spec-based command tree did not work: deployed=%v, port=%v", deployed, cmdr.GetIntR("deploy.port"))
+	}
+}
+
+func TestLoadRootCommandFromSpecJSON(t *testing.T) {
+	const spec = `{"app-name": "austr", "sub-commands": [{"full": "ls", "flags": [{"full": "limit", "type": "uint", "default": 20}]}]}`
+	root, err := cmdr.LoadRootCommandFrom(strings.NewReader(spec), ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := root.FindSubCommand("ls").FindFlag("limit").DefaultValue.(uint); !ok || v != 20 {
+		t.Fatalf("bad limit default value: %v", root.FindSubCommand("ls").FindFlag("limit").DefaultValue)
+	}
+
+	// the numbers without a type
+	const untyped = `{"sub-commands": [{"full": "ls", "flags": [{"full": "port", "default": 8080}, {"full": "ratio", "default": 0.5}, {"full": "ports", "default": [80, 443]}]}]}`
+	if root, err = cmdr.LoadRootCommandFrom(strings.NewReader(untyped), ".json"); err != nil {
+		t.Fatal(err)
+	}
+	ls := root.FindSubCommand("ls")
+	if v, ok := ls.FindFlag("port").DefaultValue.(int); !ok || v != 8080 {
+		t.Fatalf("expect the int default value 8080, but got %#v", ls.FindFlag("port").DefaultValue)
+	}
+	if v, ok := ls.FindFlag("ratio").DefaultValue.(float64); !ok || v != 0.5 {
+		t.Fatalf("expect the float64 default value 0.5, but got %#v", ls.FindFlag("ratio").DefaultValue)
+	}
+	if v, ok := ls.FindFlag("ports").DefaultValue.([]int); !ok || len(v) != 2 || v[1] != 443 {
+		t.Fatalf("expect the []int default value [80 443], but got %#v", ls.FindFlag("ports").DefaultValue)
+	}
+
+	for _, bad := range []string{
+		`{"sub-commands": [{"full": "ls", "action": "not-registered"}]}`,
+		`{"sub-commands": [{"full": "ls", "flags": [{"full": "limit", "type": "uint", "default": "x"}]}]}`,
+		`{"sub-commands": [{"full": "ls", "flags": [{"full": "limit", "type": "unknown"}]}]}`,
+		`{"sub-commands": [{"full": "ls", "flags": [{"full": "limit", "replaced-by": "max"}]}]}`,
+	} {
+		if _, err = cmdr.LoadRootCommandFrom(strings.NewReader(bad), ".json"); err == nil {
+			t.Fatalf("expecting an error for spec: %v", bad)
+		}
+	}
+
+	if _, err = cmdr.LoadRootCommandFromFile("not-exists.yml"); err == nil {
+		t.Fatal("expecting an error for a non-existent spec file")
+	}
+}
+
+func TestGenerateGoSource(t *testing.T) {
+	spec, err := cmdr.LoadRootSpecFrom(strings.NewReader(specForTesting), ".yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "cmdr-gen-code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files, err := cmdr.GenerateGoSource(spec, dir, "cli", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expecting the tree file and one stub file, but got: %v", files)
+	}
+
+	b, err := ioutil.ReadFile(path.Join(dir, "cmdr_tree_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"package cli",
+		"func BuildRootCmd() (rootCmd *cmdr.RootCommand)",
+		`root.NewSubCommand("deploy", "d", "dep")`,
+		"Action(specDeployAction)",
+		`NewFlagV(5*time.Second, "timeout")`,
+		`ValidArgs("debug", "info", "warn")`,
+		"Required(true)",
+		`RemovedIn("2.0.0")`,
+		"cmdDeployListenFlag.ReplacedBy(cmdDeployPortFlag)",
+	} {
+		if !strings.Contains(string(b), s) {
+			t.Fatalf("expecting %q in the generated codes:\n%s", s, b)
+		}
+	}
+
+	// the stubs must be kept on regeneration
+	stub := path.Join(dir, "action_spec_deploy.go")
+	if err = ioutil.WriteFile(stub, []byte("package cli\n\nfunc specDeployAction() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if files, err = cmdr.GenerateGoSource(spec, dir, "cli", ""); err != nil || len(files) != 1 {
+		t.Fatalf("regeneration failed: %v, %v", files, err)
+	}
+	if b, _ = ioutil.ReadFile(stub); !strings.Contains(string(b), "func specDeployAction() {}") {
+		t.Fatalf("the stub file was overwritten:\n%s", b)
+	}
+}
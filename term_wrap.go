@@ -10,6 +10,7 @@ package cmdr
 import (
 	"fmt"
 	"golang.org/x/crypto/ssh/terminal"
+	"os"
 	"syscall"
 )
 
@@ -23,3 +24,15 @@ func readPassword() (text string, err error) {
 	}
 	return
 }
+
+func isTerminal(f *os.File) bool {
+	return terminal.IsTerminal(int(f.Fd()))
+}
+
+func terminalSize(f *os.File) (width, height int) {
+	var err error
+	if width, height, err = terminal.GetSize(int(f.Fd())); err != nil {
+		width, height = 0, 0
+	}
+	return
+}
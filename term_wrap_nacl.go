@@ -6,6 +6,16 @@
 
 package cmdr
 
+import "os"
+
 func readPassword() (text string, err error) {
 	return randomStringPure(9), nil
 }
+
+func isTerminal(f *os.File) bool {
+	return false
+}
+
+func terminalSize(f *os.File) (width, height int) {
+	return
+}
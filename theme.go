@@ -0,0 +1,150 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr
+
+import (
+	"gopkg.in/hedzr/errors.v2"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Theme is a set of the colors to print the help screen, the tree and
+// the search results.
+type Theme struct {
+	// Description is the color of the description of commands and flags
+	Description Color
+	// DefaultValue is the color of the default values and env vars
+	DefaultValue Color
+	// GroupTitle is the color of the group titles and the tail line
+	GroupTitle Color
+	// Highlight is the color of the matched words of --help-search
+	Highlight Color
+}
+
+var (
+	themes = map[string]*Theme{
+		"default": {FgDarkGray, FgDarkGray, DarkColor, FgLightYellow},
+		"dark":    {Color256(250), Color256(244), Color256(110), Color256(220)},
+		"light":   {Color256(238), Color256(242), Color256(25), Color256(166)},
+	}
+	themesRW sync.RWMutex
+
+	// currentTheme holds the colors to print, the int vars such as
+	// CurrentDescColor hold their basic fallbacks.
+	currentTheme = Theme{FgDarkGray, FgDarkGray, DarkColor, FgLightYellow}
+)
+
+// RegisterTheme adds a named theme or replaces the existing one, so
+// that it can be selected by WithTheme or the config entry
+// `app.theme.name`.
+func RegisterTheme(name string, theme *Theme) {
+	themesRW.Lock()
+	defer themesRW.Unlock()
+	themes[strings.ToLower(name)] = theme
+}
+
+// GetTheme returns the registered theme by name, or nil if not found.
+func GetTheme(name string) *Theme {
+	themesRW.RLock()
+	defer themesRW.RUnlock()
+	return themes[strings.ToLower(name)]
+}
+
+// ApplyTheme makes the theme current. The int vars such as
+// CurrentDescColor get the nearest basic ANSI colors, so they are
+// still valid in "\x1b[%dm" for a 256-color or 24-bit theme.
+func ApplyTheme(theme *Theme) {
+	currentTheme = *theme
+	CurrentDescColor = theme.Description.basic()
+	CurrentDefaultValueColor = theme.DefaultValue.basic()
+	CurrentGroupTitleColor = theme.GroupTitle.basic()
+	CurrentHighlightColor = theme.Highlight.basic()
+}
+
+// themeColor returns the color c of the current theme, or the int var
+// if it has been changed directly.
+func themeColor(c Color, basic int) Color {
+	if c.basic() == basic {
+		return c
+	}
+	return Color(basic)
+}
+
+func descColor() Color { return themeColor(currentTheme.Description, CurrentDescColor) }
+
+func defaultValueColor() Color {
+	return themeColor(currentTheme.DefaultValue, CurrentDefaultValueColor)
+}
+
+func groupTitleColor() Color { return themeColor(currentTheme.GroupTitle, CurrentGroupTitleColor) }
+
+func highlightColor() Color { return themeColor(currentTheme.Highlight, CurrentHighlightColor) }
+
+// applyTheme applies the theme selected by WithTheme or the config
+// entry `app.theme.name`, and then the colors in config file:
+//
+//     app:
+//       theme:
+//         name: dark
+//         description: dark-gray     # name, 0..255 or #rrggbb
+//         default-value: 244
+//         group-title: "#5f87af"
+//         highlight: light-yellow
+func (w *ExecWorker) applyTheme() (err error) {
+	theme, changed := Theme{descColor(), defaultValueColor(), groupTitleColor(), highlightColor()}, false
+	name := w.theme
+	if s := GetStringR("theme.name"); len(s) > 0 {
+		name = s
+	}
+	if len(name) > 0 {
+		t := GetTheme(name)
+		if t == nil {
+			return errors.New("unknown theme %q", name)
+		}
+		theme, changed = *t, true
+	}
+
+	for key, c := range map[string]*Color{
+		"theme.description":   &theme.Description,
+		"theme.default-value": &theme.DefaultValue,
+		"theme.group-title":   &theme.GroupTitle,
+		"theme.highlight":     &theme.Highlight,
+	} {
+		if s := GetStringR(key); len(s) > 0 {
+			if *c, err = ParseColor(s); err != nil {
+				return
+			}
+			changed = true
+		}
+	}
+	if changed {
+		ApplyTheme(&theme)
+	}
+	return
+}
+
+// detectColors disables the colored outputs if the stdout or the stderr
+// isn't a terminal, or the env vars ask for it.
+func (w *ExecWorker) detectColors() {
+	w.noColorStdout = !colorEnabled(os.Stdout, w.customStdout)
+	w.noColorStderr = !colorEnabled(os.Stderr, false)
+}
+
+// colorEnabled checks the env vars by the conventions:
+//
+//  - NO_COLOR (https://no-color.org/) disables the colors,
+//  - CLICOLOR_FORCE=1 enables the colors even if f isn't a terminal,
+//  - CLICOLOR=0 or TERM=dumb disables the colors.
+func colorEnabled(f *os.File, redirected bool) bool {
+	if len(os.Getenv("NO_COLOR")) > 0 {
+		return false
+	}
+	if s := os.Getenv("CLICOLOR_FORCE"); len(s) > 0 && s != "0" {
+		return true
+	}
+	if os.Getenv("CLICOLOR") == "0" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return !redirected && isTerminal(f)
+}
@@ -0,0 +1,101 @@
+// Copyright © 2020 Hedzr Yeh.
+
+package cmdr_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hedzr/cmdr"
+)
+
+func TestParseColor(t *testing.T) {
+	defer os.Setenv("TERM", os.Getenv("TERM"))
+	defer os.Setenv("COLORTERM", os.Getenv("COLORTERM"))
+
+	for _, c := range []struct {
+		s, term, colorTerm, expect string
+	}{
+		{"light-blue", "xterm", "", "94"},
+		{"Dark_Gray", "xterm", "", "90"},
+		{"208", "xterm-256color", "", "38;5;208"},
+		{"226", "xterm", "", "93"},
+		{"#ff8800", "xterm", "truecolor", "38;2;255;136;0"},
+		{"#ff8800", "xterm-256color", "", "38;5;208"},
+	} {
+		_ = os.Setenv("TERM", c.term)
+		_ = os.Setenv("COLORTERM", c.colorTerm)
+		color, err := cmdr.ParseColor(c.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := fmt.Sprintf("%d", color); s != c.expect {
+			t.Fatalf("%q (TERM=%v, COLORTERM=%v): expecting %q but got %q", c.s, c.term, c.colorTerm, c.expect, s)
+		}
+	}
+
+	if _, err := cmdr.ParseColor("not-a-color"); err == nil {
+		t.Fatal("expecting an error for the unknown color")
+	}
+}
+
+func TestColorDetection(t *testing.T) {
+	defer resetOsArgs()
+	defer os.Unsetenv("NO_COLOR")
+	defer os.Unsetenv("CLICOLOR_FORCE")
+	defer cmdr.ApplyTheme(cmdr.GetTheme("default"))
+	defer os.Setenv("TERM", os.Getenv("TERM"))
+	_ = os.Setenv("TERM", "xterm-256color")
+
+	root := cmdr.Root("colors-app", "1.0.0")
+	root.NewSubCommand("deploy").Description("deploy the services", "")
+
+	for _, c := range []struct {
+		env     map[string]string
+		opts    []cmdr.ExecOption
+		colored bool
+	}{
+		{nil, nil, false}, // the stdout is redirected
+		{map[string]string{"CLICOLOR_FORCE": "1"}, nil, true},
+		{map[string]string{"CLICOLOR_FORCE": "1", "NO_COLOR": "1"}, nil, false},
+		{map[string]string{"CLICOLOR_FORCE": "1"}, []cmdr.ExecOption{cmdr.WithTheme("light")}, true},
+	} {
+		cmdr.ResetOptions()
+		cmdr.InternalResetWorker()
+		outX, _ := prepareStreams()
+
+		for _, k := range []string{"NO_COLOR", "CLICOLOR_FORCE"} {
+			_ = os.Setenv(k, c.env[k])
+		}
+		os.Args = []string{"colors-app", "--help"}
+		opts := append([]cmdr.ExecOption{cmdr.WithNoLoadConfigFiles(true)}, c.opts...)
+		if err := cmdr.Exec(root.RootCommand(), opts...); err != nil {
+			t.Fatal(err)
+		}
+		cmdr.InternalResetWorker() // flush the outputs
+
+		if out := outX.String(); strings.Contains(out, "\x1b[") != c.colored {
+			t.Fatalf("%v: colored should be %v:\n%q", c.env, c.colored, out)
+		}
+		if len(c.opts) > 0 && (cmdr.CurrentDescColor != cmdr.FgDarkGray || !strings.Contains(outX.String(), "\x1b[38;5;238m")) {
+			t.Fatalf("the theme 'light' wasn't applied (CurrentDescColor=%v):\n%q", cmdr.CurrentDescColor, outX.String())
+		}
+	}
+	cmdr.ResetOptions()
+}
+
+func TestApplyTheme(t *testing.T) {
+	defer cmdr.ApplyTheme(cmdr.GetTheme("default"))
+
+	cmdr.ApplyTheme(cmdr.GetTheme("dark"))
+	for _, c := range []int{cmdr.CurrentDescColor, cmdr.CurrentDefaultValueColor, cmdr.CurrentGroupTitleColor, cmdr.CurrentHighlightColor} {
+		if !(c >= cmdr.FgBlack && c <= cmdr.FgLightGray || c >= cmdr.FgDarkGray && c <= cmdr.FgWhite) {
+			t.Fatalf("expecting the basic ANSI colors, but got %v", c)
+		}
+	}
+	if s := fmt.Sprintf("\x1b[%dm", cmdr.CurrentDescColor); s != "\x1b[97m" {
+		t.Fatalf("bad SGR for the 256-color 250: %q", s)
+	}
+}
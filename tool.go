@@ -171,7 +171,7 @@ func manExamples(s string, data interface{}) string {
 
 func tplApply(tmpl string, data interface{}) string {
 	var w = new(bytes.Buffer)
-	var tpl = template.Must(template.New("x").Parse(tmpl))
+	var tpl = template.Must(template.New("x").Funcs(template.FuncMap{"T": T}).Parse(tmpl))
 	if err := tpl.Execute(w, data); err != nil {
 		log.Printf("tpl execute error: %v", err)
 		return ""
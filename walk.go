@@ -24,3 +24,20 @@ func walkFromCommand(cmd *Command, index int, walk func(cmd *Command, index int)
 	}
 	return
 }
+
+// walkVisibleCommands loops for the commands from cmd, and skips the
+// hidden commands with their sub-commands.
+func walkVisibleCommands(cmd *Command, index int, walk func(cmd *Command, index int) (err error)) (err error) {
+	if cmd.Hidden {
+		return
+	}
+	err = walk(cmd, index)
+	if err == nil {
+		for ix, cc := range cmd.SubCommands {
+			if err = walkVisibleCommands(cc, ix, walk); err != nil {
+				return
+			}
+		}
+	}
+	return
+}